@@ -100,16 +100,18 @@ func BenchmarkListView100(b *testing.B) {
 }
 
 func BenchmarkListNavigate100(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		l := list.New(buildItems(100), list.NewDefaultDelegate(), 80, 20)
-		for j := 0; j < 10; j++ {
-			l.CursorDown()
-		}
-		for j := 0; j < 5; j++ {
-			l.CursorUp()
+	WithProfiles(b, b.Name(), func() {
+		for i := 0; i < b.N; i++ {
+			l := list.New(buildItems(100), list.NewDefaultDelegate(), 80, 20)
+			for j := 0; j < 10; j++ {
+				l.CursorDown()
+			}
+			for j := 0; j < 5; j++ {
+				l.CursorUp()
+			}
+			_ = l.SelectedItem()
 		}
-		_ = l.SelectedItem()
-	}
+	})
 }
 
 func BenchmarkListFilter100(b *testing.B) {
@@ -187,15 +189,17 @@ func BenchmarkTableSetColumnsRows(b *testing.B) {
 	columns := buildTableColumns()
 	rows := buildTableRows(150)
 	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		t := table.New(
-			table.WithWidth(80),
-			table.WithHeight(20),
-		)
-		t.SetColumns(columns)
-		t.SetRows(rows)
-		_ = t.View()
-	}
+	WithProfiles(b, b.Name(), func() {
+		for i := 0; i < b.N; i++ {
+			t := table.New(
+				table.WithWidth(80),
+				table.WithHeight(20),
+			)
+			t.SetColumns(columns)
+			t.SetRows(rows)
+			_ = t.View()
+		}
+	})
 }
 
 // Viewport Benchmarks - matches bubbles/viewport group
@@ -232,15 +236,17 @@ func BenchmarkViewportRender10000(b *testing.B) {
 
 func BenchmarkViewportScrollOps(b *testing.B) {
 	content := buildViewportContent(2000)
-	for i := 0; i < b.N; i++ {
-		vp := viewport.New(80, 24)
-		vp.SetContent(content)
-		vp.LineDown(5)
-		vp.LineUp(2)
-		vp.HalfViewDown()
-		vp.HalfViewUp()
-		_ = vp.View()
-	}
+	WithProfiles(b, b.Name(), func() {
+		for i := 0; i < b.N; i++ {
+			vp := viewport.New(80, 24)
+			vp.SetContent(content)
+			vp.LineDown(5)
+			vp.LineUp(2)
+			vp.HalfViewDown()
+			vp.HalfViewUp()
+			_ = vp.View()
+		}
+	})
 }
 
 // TextInput Benchmarks - matches bubbles/textinput group
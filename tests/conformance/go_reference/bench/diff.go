@@ -0,0 +1,104 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/pprof/profile"
+)
+
+// FunctionDelta is one function's flat-sample delta between two profiles.
+type FunctionDelta struct {
+	Name      string
+	OldFlat   int64
+	NewFlat   int64
+	DeltaFlat int64
+}
+
+// Delta is the result of DiffProfiles: every function seen in either
+// profile, ordered by the magnitude of its change (largest first).
+type Delta struct {
+	Functions []FunctionDelta
+}
+
+// TopN returns the n functions with the largest absolute delta, or every
+// function if there are fewer than n.
+func (d Delta) TopN(n int) []FunctionDelta {
+	if n > len(d.Functions) {
+		n = len(d.Functions)
+	}
+	return d.Functions[:n]
+}
+
+// DiffProfiles loads two pprof profiles (e.g. a baseline and current
+// .cpu.pb.gz from WithProfiles) and computes each function's flat-sample
+// delta, so a regression in one bench run can be pinned to the specific
+// Bubbles/Bubbletea change that caused it.
+func DiffProfiles(oldPath, newPath string) (Delta, error) {
+	oldProf, err := loadProfile(oldPath)
+	if err != nil {
+		return Delta{}, fmt.Errorf("bench: loading baseline profile: %w", err)
+	}
+	newProf, err := loadProfile(newPath)
+	if err != nil {
+		return Delta{}, fmt.Errorf("bench: loading current profile: %w", err)
+	}
+
+	oldFlat := flatByFunction(oldProf)
+	newFlat := flatByFunction(newProf)
+
+	seen := make(map[string]bool, len(oldFlat)+len(newFlat))
+	for name := range oldFlat {
+		seen[name] = true
+	}
+	for name := range newFlat {
+		seen[name] = true
+	}
+
+	functions := make([]FunctionDelta, 0, len(seen))
+	for name := range seen {
+		o, n := oldFlat[name], newFlat[name]
+		functions = append(functions, FunctionDelta{Name: name, OldFlat: o, NewFlat: n, DeltaFlat: n - o})
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		return abs64(functions[i].DeltaFlat) > abs64(functions[j].DeltaFlat)
+	})
+
+	return Delta{Functions: functions}, nil
+}
+
+func loadProfile(path string) (*profile.Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return profile.Parse(f)
+}
+
+// flatByFunction sums each sample's first value (the profile's primary
+// metric, e.g. cpu nanoseconds or bytes allocated) onto the function at
+// the top of its call stack -- pprof's definition of "flat".
+func flatByFunction(p *profile.Profile) map[string]int64 {
+	flat := make(map[string]int64)
+	for _, s := range p.Sample {
+		if len(s.Location) == 0 || len(s.Value) == 0 {
+			continue
+		}
+		loc := s.Location[0]
+		name := "unknown"
+		if len(loc.Line) > 0 && loc.Line[0].Function != nil {
+			name = loc.Line[0].Function.Name
+		}
+		flat[name] += s.Value[0]
+	}
+	return flat
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -2,10 +2,13 @@ package bench
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/charmbracelet/glamour"
+
+	"charmed_conformance/internal/glamourstream"
 )
 
 const smallDoc = `# Hello World
@@ -63,6 +66,7 @@ func generateLargeDoc() string {
 func BenchmarkRenderSmall(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	b.SetBytes(int64(len(smallDoc)))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(smallDoc)
@@ -72,6 +76,7 @@ func BenchmarkRenderSmall(b *testing.B) {
 func BenchmarkRenderMedium(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	b.SetBytes(int64(len(mediumDoc)))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(mediumDoc)
@@ -82,6 +87,7 @@ func BenchmarkRenderLarge(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	largeDoc := generateLargeDoc()
 	b.SetBytes(int64(len(largeDoc)))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(largeDoc)
@@ -98,6 +104,7 @@ func BenchmarkHeaders(b *testing.B) {
 		sb.WriteString(fmt.Sprintf(" Header Level %d\n\n", n))
 	}
 	headers := strings.Repeat(sb.String(), 100)
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(headers)
@@ -111,6 +118,7 @@ func BenchmarkUnorderedList100(b *testing.B) {
 		sb.WriteString(fmt.Sprintf("- Item %d\n", i))
 	}
 	list := sb.String()
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(list)
@@ -126,6 +134,7 @@ func BenchmarkNestedList(b *testing.B) {
 		sb.WriteString(fmt.Sprintf("    - Deep %d\n", i))
 	}
 	nestedList := sb.String()
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(nestedList)
@@ -136,6 +145,7 @@ func BenchmarkCodeBlocks50(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	codeBlock := "```rust\nfn main() {\n    println!(\"Hello\");\n}\n```\n"
 	codeBlocks := strings.Repeat(codeBlock, 50)
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(codeBlocks)
@@ -149,6 +159,7 @@ func BenchmarkLinksEmphasis100(b *testing.B) {
 		sb.WriteString(fmt.Sprintf("[Link %d](https://example.com/%d) and **bold** and *italic*\n", i, i))
 	}
 	links := sb.String()
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(links)
@@ -159,6 +170,7 @@ func BenchmarkTables50(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	table := "| Col 1 | Col 2 | Col 3 |\n|-------|-------|-------|\n| A | B | C |\n"
 	tables := strings.Repeat(table, 50)
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(tables)
@@ -169,6 +181,7 @@ func BenchmarkTables50(b *testing.B) {
 
 func BenchmarkDefaultDark(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(mediumDoc)
@@ -177,6 +190,7 @@ func BenchmarkDefaultDark(b *testing.B) {
 
 func BenchmarkLightStyle(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("light"))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(mediumDoc)
@@ -185,6 +199,7 @@ func BenchmarkLightStyle(b *testing.B) {
 
 func BenchmarkASCIIStyle(b *testing.B) {
 	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("ascii"))
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = r.Render(mediumDoc)
@@ -193,7 +208,67 @@ func BenchmarkASCIIStyle(b *testing.B) {
 
 // Renderer creation benchmark
 func BenchmarkRendererCreate(b *testing.B) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_, _ = glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
 	}
 }
+
+// chunkReader delivers data in fixed-size reads rather than all at once,
+// simulating a streamed source (e.g. a network body) instead of the
+// in-memory strings.Reader every other benchmark here reads from.
+type chunkReader struct {
+	data      string
+	chunkSize int
+	pos       int
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if c.pos >= len(c.data) {
+		return 0, io.EOF
+	}
+	n := c.chunkSize
+	if remaining := len(c.data) - c.pos; remaining < n {
+		n = remaining
+	}
+	if n > len(p) {
+		n = len(p)
+	}
+	copy(p, c.data[c.pos:c.pos+n])
+	c.pos += n
+	return n, nil
+}
+
+// BenchmarkRenderStreaming measures rendering a large (about 1.7MB) document
+// delivered through a chunked io.Reader via glamourstream.RenderReader,
+// rather than buffered up front as a single string -- the pattern a
+// terminal markdown viewer piped stdin (e.g. `curl | glow`) actually sees.
+func BenchmarkRenderStreaming(b *testing.B) {
+	hugeDoc := strings.Repeat(generateLargeDoc(), 200)
+	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
+	b.SetBytes(int64(len(hugeDoc)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		src := &chunkReader{data: hugeDoc, chunkSize: 4096}
+		if err := glamourstream.RenderReader(r, src, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRenderParallel renders mediumDoc through a single shared
+// TermRenderer from multiple goroutines at once, to surface any
+// contention on state the renderer keeps internally across calls --
+// contention none of the sequential benchmarks above would ever show.
+func BenchmarkRenderParallel(b *testing.B) {
+	r, _ := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
+	b.SetBytes(int64(len(mediumDoc)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = r.Render(mediumDoc)
+		}
+	})
+}
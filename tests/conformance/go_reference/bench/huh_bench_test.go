@@ -0,0 +1,32 @@
+package bench
+
+import (
+	"fmt"
+	"testing"
+
+	"charmed_conformance/internal/fuzzyfilter"
+)
+
+func buildFilterCandidates(count int) []string {
+	candidates := make([]string, count)
+	for i := 0; i < count; i++ {
+		candidates[i] = fmt.Sprintf("us-east-%d/instance_group-%d.yaml", i%8, i)
+	}
+	return candidates
+}
+
+func BenchmarkSelectFilterV1(b *testing.B) {
+	candidates := buildFilterCandidates(500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = fuzzyfilter.FilterV1("iggy", candidates)
+	}
+}
+
+func BenchmarkSelectFilterV2(b *testing.B) {
+	candidates := buildFilterCandidates(500)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = fuzzyfilter.FilterV2("iggy", candidates)
+	}
+}
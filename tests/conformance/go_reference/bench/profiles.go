@@ -0,0 +1,149 @@
+package bench
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var profilesDir = flag.String("profiles-dir", "",
+	"Directory to write per-benchmark CPU/heap/alloc pprof profiles and an execution trace to; disabled when empty")
+
+var manifestMu sync.Mutex
+
+// manifestEntry is one profile file's record in a run directory's
+// manifest.json: which benchmark produced it, its checksum, and the
+// commit the suite was run at.
+type manifestEntry struct {
+	Benchmark string `json:"benchmark"`
+	File      string `json:"file"`
+	SHA256    string `json:"sha256"`
+	GitCommit string `json:"git_commit"`
+}
+
+// WithProfiles runs fn as the benchmark's timed work and, when -profiles-dir
+// is set, wraps it with a CPU profile, a heap profile, an allocation
+// profile, and an execution trace, so a regression in e.g.
+// BenchmarkListNavigate100, BenchmarkTableSetColumnsRows, or
+// BenchmarkViewportScrollOps can be traced to a specific function without
+// re-running the benchmark under a separate profiler by hand. With no
+// -profiles-dir, it's a plain passthrough.
+func WithProfiles(b *testing.B, name string, fn func()) {
+	dir := *profilesDir
+	if dir == "" {
+		fn()
+		return
+	}
+
+	runDir := filepath.Join(dir, fmt.Sprintf("%s-%s-%s", runtime.GOOS, runtime.GOARCH, runtime.Version()))
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		b.Fatalf("bench: creating profiles dir: %v", err)
+	}
+
+	cpuFile, err := os.Create(filepath.Join(runDir, name+".cpu.pb.gz"))
+	if err != nil {
+		b.Fatalf("bench: creating cpu profile: %v", err)
+	}
+	defer cpuFile.Close()
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		b.Fatalf("bench: starting cpu profile: %v", err)
+	}
+
+	traceFile, err := os.Create(filepath.Join(runDir, name+".trace.pb.gz"))
+	if err != nil {
+		b.Fatalf("bench: creating trace file: %v", err)
+	}
+	defer traceFile.Close()
+	if err := trace.Start(traceFile); err != nil {
+		b.Fatalf("bench: starting trace: %v", err)
+	}
+
+	fn()
+
+	trace.Stop()
+	pprof.StopCPUProfile()
+
+	writeRuntimeProfile(b, runDir, name, "heap")
+	writeRuntimeProfile(b, runDir, name, "allocs")
+
+	recordManifest(runDir, name, []string{
+		name + ".cpu.pb.gz",
+		name + ".heap.pb.gz",
+		name + ".alloc.pb.gz",
+		name + ".trace.pb.gz",
+	})
+}
+
+// writeRuntimeProfile writes one of runtime/pprof's named profiles
+// ("heap" or "allocs") to <dir>/<name>.<ext>.pb.gz, where ext is "heap"
+// for the heap profile and "alloc" for the allocation profile.
+func writeRuntimeProfile(b *testing.B, dir, name, profileName string) {
+	ext := "alloc"
+	if profileName == "heap" {
+		ext = "heap"
+		runtime.GC() // a fresh GC keeps the heap profile's live-set meaningful
+	}
+
+	f, err := os.Create(filepath.Join(dir, name+"."+ext+".pb.gz"))
+	if err != nil {
+		b.Fatalf("bench: creating %s profile: %v", profileName, err)
+	}
+	defer f.Close()
+
+	if err := pprof.Lookup(profileName).WriteTo(f, 0); err != nil {
+		b.Fatalf("bench: writing %s profile: %v", profileName, err)
+	}
+}
+
+// recordManifest appends checksum entries for files to <dir>/manifest.json,
+// mapping benchmark name -> file -> SHA256 -> git commit.
+func recordManifest(dir, benchName string, files []string) {
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	manifestPath := filepath.Join(dir, "manifest.json")
+	var entries []manifestEntry
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		_ = json.Unmarshal(data, &entries)
+	}
+
+	commit := gitCommit()
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			continue // profile wasn't produced (e.g. an empty trace) -- skip it rather than fail the benchmark
+		}
+		sum := sha256.Sum256(data)
+		entries = append(entries, manifestEntry{
+			Benchmark: benchName,
+			File:      f,
+			SHA256:    hex.EncodeToString(sum[:]),
+			GitCommit: commit,
+		})
+	}
+
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(manifestPath, out, 0644)
+}
+
+func gitCommit() string {
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,224 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"charmed_conformance/internal/syntax"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Benchmarks for the syntax package's token-run renderer - matches
+// lipgloss/highlighted group. BenchmarkRenderShortComplex exercises a
+// single style on short plain text; these measure throughput on realistic
+// mixed-style code listings instead.
+
+func defaultSyntaxTheme() syntax.SyntaxTheme {
+	return syntax.SyntaxTheme{
+		syntax.Keyword:     lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true),
+		syntax.String:      lipgloss.NewStyle().Foreground(lipgloss.Color("114")),
+		syntax.Comment:     lipgloss.NewStyle().Foreground(lipgloss.Color("243")).Italic(true),
+		syntax.Number:      lipgloss.NewStyle().Foreground(lipgloss.Color("215")),
+		syntax.Operator:    lipgloss.NewStyle().Foreground(lipgloss.Color("39")),
+		syntax.Punctuation: lipgloss.NewStyle().Foreground(lipgloss.Color("250")),
+	}
+}
+
+var goCorpus = `package main
+
+// greet prints a friendly greeting count times.
+func greet(name string, count int) {
+	for i := 0; i < count; i++ {
+		fmt.Println("Hello, " + name + "!")
+	}
+}
+`
+
+var jsonCorpus = `{
+  "name": "charmed_conformance",
+  "version": 3,
+  "stable": true,
+  "tags": ["cli", "tui", "rust-port"],
+  "limits": {"max_width": 120, "ratio": 0.75}
+}
+`
+
+var diffCorpus = `--- a/src/main.rs
++++ b/src/main.rs
+@@ -10,7 +10,7 @@ fn main() {
+-    let count = 0;
++    let count = 1;
+     println!("count = {}", count);
+ }
+`
+
+var goKeywords = map[string]bool{
+	"package": true, "func": true, "for": true, "if": true, "return": true, "var": true,
+}
+
+// tokenizeGo is a naive word/punctuation tokenizer, good enough to drive a
+// realistic mixed-style benchmark without depending on a real Go lexer.
+func tokenizeGo(src string) []syntax.Token {
+	var tokens []syntax.Token
+	lines := strings.Split(src, "\n")
+	for li, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			tokens = append(tokens, syntax.Token{Type: syntax.Comment, Text: line})
+		} else {
+			tokens = append(tokens, tokenizeGoLine(line)...)
+		}
+		if li < len(lines)-1 {
+			tokens = append(tokens, syntax.Token{Type: syntax.Text, Text: "\n"})
+		}
+	}
+	return tokens
+}
+
+func tokenizeGoLine(line string) []syntax.Token {
+	var tokens []syntax.Token
+	var word strings.Builder
+
+	flushWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := word.String()
+		switch {
+		case goKeywords[w]:
+			tokens = append(tokens, syntax.Token{Type: syntax.Keyword, Text: w})
+		case unicode.IsDigit(rune(w[0])):
+			tokens = append(tokens, syntax.Token{Type: syntax.Number, Text: w})
+		default:
+			tokens = append(tokens, syntax.Token{Type: syntax.Identifier, Text: w})
+		}
+		word.Reset()
+	}
+
+	inString := false
+	var str strings.Builder
+	for _, r := range line {
+		switch {
+		case inString:
+			str.WriteRune(r)
+			if r == '"' {
+				tokens = append(tokens, syntax.Token{Type: syntax.String, Text: str.String()})
+				str.Reset()
+				inString = false
+			}
+		case r == '"':
+			inString = true
+			str.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			word.WriteRune(r)
+		case strings.ContainsRune("+-*/<>=!", r):
+			flushWord()
+			tokens = append(tokens, syntax.Token{Type: syntax.Operator, Text: string(r)})
+		case strings.ContainsRune("(){}[];:,.", r):
+			flushWord()
+			tokens = append(tokens, syntax.Token{Type: syntax.Punctuation, Text: string(r)})
+		default:
+			flushWord()
+			tokens = append(tokens, syntax.Token{Type: syntax.Text, Text: string(r)})
+		}
+	}
+	if inString {
+		tokens = append(tokens, syntax.Token{Type: syntax.String, Text: str.String()})
+	}
+	flushWord()
+	return tokens
+}
+
+// tokenizeJSON classifies each line by its dominant shape: a quoted key, a
+// string value, a number/bool/null literal, or plain punctuation.
+func tokenizeJSON(src string) []syntax.Token {
+	var tokens []syntax.Token
+	lines := strings.Split(src, "\n")
+	for li, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.Contains(trimmed, `":`):
+			colon := strings.Index(line, `":`) + 1
+			tokens = append(tokens,
+				syntax.Token{Type: syntax.String, Text: line[:colon]},
+				syntax.Token{Type: syntax.Punctuation, Text: ":"},
+				classifyJSONValue(line[colon+1:]),
+			)
+		case trimmed == "true" || trimmed == "false" || trimmed == "null":
+			tokens = append(tokens, syntax.Token{Type: syntax.Keyword, Text: line})
+		default:
+			tokens = append(tokens, syntax.Token{Type: syntax.Punctuation, Text: line})
+		}
+		if li < len(lines)-1 {
+			tokens = append(tokens, syntax.Token{Type: syntax.Text, Text: "\n"})
+		}
+	}
+	return tokens
+}
+
+func classifyJSONValue(value string) syntax.Token {
+	trimmed := strings.TrimSpace(strings.TrimSuffix(value, ","))
+	switch {
+	case strings.HasPrefix(trimmed, `"`):
+		return syntax.Token{Type: syntax.String, Text: value}
+	case trimmed == "true" || trimmed == "false" || trimmed == "null":
+		return syntax.Token{Type: syntax.Keyword, Text: value}
+	case trimmed != "" && (unicode.IsDigit(rune(trimmed[0])) || trimmed[0] == '-'):
+		return syntax.Token{Type: syntax.Number, Text: value}
+	default:
+		return syntax.Token{Type: syntax.Punctuation, Text: value}
+	}
+}
+
+// tokenizeDiff classifies each line by its leading unified-diff marker.
+func tokenizeDiff(src string) []syntax.Token {
+	var tokens []syntax.Token
+	lines := strings.Split(src, "\n")
+	for li, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			tokens = append(tokens, syntax.Token{Type: syntax.String, Text: line})
+		case strings.HasPrefix(line, "-"):
+			tokens = append(tokens, syntax.Token{Type: syntax.Keyword, Text: line})
+		case strings.HasPrefix(line, "@@"):
+			tokens = append(tokens, syntax.Token{Type: syntax.Comment, Text: line})
+		default:
+			tokens = append(tokens, syntax.Token{Type: syntax.Text, Text: line})
+		}
+		if li < len(lines)-1 {
+			tokens = append(tokens, syntax.Token{Type: syntax.Text, Text: "\n"})
+		}
+	}
+	return tokens
+}
+
+func BenchmarkRenderHighlightedGo(b *testing.B) {
+	theme := defaultSyntaxTheme()
+	tokens := tokenizeGo(goCorpus)
+	b.SetBytes(int64(len(goCorpus)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = syntax.Render(tokens, theme)
+	}
+}
+
+func BenchmarkRenderHighlightedJSON(b *testing.B) {
+	theme := defaultSyntaxTheme()
+	tokens := tokenizeJSON(jsonCorpus)
+	b.SetBytes(int64(len(jsonCorpus)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = syntax.Render(tokens, theme)
+	}
+}
+
+func BenchmarkRenderHighlightedDiff(b *testing.B) {
+	theme := defaultSyntaxTheme()
+	tokens := tokenizeDiff(diffCorpus)
+	b.SetBytes(int64(len(diffCorpus)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = syntax.Render(tokens, theme)
+	}
+}
@@ -0,0 +1,50 @@
+// Benchreport - runs the bench package's benchmarks for one crate and
+// exports the results as Prometheus/OpenMetrics text, optionally pushing
+// them to a Pushgateway instance for long-running drift tracking.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"charmed_conformance/internal/benchreport"
+)
+
+func main() {
+	pkgDir := flag.String("pkg", "../../bench", "Directory containing the bench package to run")
+	pattern := flag.String("pattern", ".", "Benchmark name pattern passed to -bench")
+	crate := flag.String("crate", "", "Crate label to attach to every result (required)")
+	outputDir := flag.String("output", "output", "Output directory for the <crate>.prom file")
+	gateway := flag.String("gateway", "", "Pushgateway base URL; if set, results are pushed instead of written to disk")
+	job := flag.String("job", "charmed-conformance", "Pushgateway job name")
+	flag.Parse()
+
+	if *crate == "" {
+		fmt.Fprintln(os.Stderr, "Error: -crate is required")
+		os.Exit(1)
+	}
+
+	raws, err := benchreport.RunBenchmarks(*pkgDir, *pattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	results := benchreport.ToResults(*crate, raws)
+
+	if *gateway != "" {
+		if err := benchreport.PushToGateway(*gateway, *job, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Pushed %d bench results for %s to %s\n", len(results), *crate, *gateway)
+		return
+	}
+
+	if err := benchreport.ExportProm(*outputDir, *crate, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d bench results for %s to %s/%s.prom\n", len(results), *crate, *outputDir, *crate)
+}
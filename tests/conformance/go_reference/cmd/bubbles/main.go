@@ -2,14 +2,26 @@
 package main
 
 import (
+	"charmed_conformance/internal/bulkrename"
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/filesystems"
+	"charmed_conformance/internal/fsops"
+	"charmed_conformance/internal/hscroll"
+	"charmed_conformance/internal/miller"
+	"charmed_conformance/internal/search"
+	"charmed_conformance/internal/sorting"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/help"
@@ -20,13 +32,14 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/stopwatch"
 	"github.com/charmbracelet/bubbles/table"
-	"github.com/charmbracelet/bubbles/timer"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/timer"
 	"github.com/charmbracelet/bubbles/viewport"
 )
 
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	format := flag.String("format", "json", "Fixture format: json, yaml, msgpack, cbor, or jsonl")
 	flag.Parse()
 
 	fixtures := capture.NewFixtureSet("bubbles", "0.20.0")
@@ -34,6 +47,15 @@ func main() {
 	// Capture viewport behaviors
 	captureViewportTests(fixtures)
 
+	// Capture viewport soft-wrap/reflow behaviors
+	captureViewportReflowTests(fixtures)
+
+	// Capture viewport ANSI/OSC/DECSET passthrough behaviors
+	captureViewportAnsiPassthroughTests(fixtures)
+
+	// Capture viewport horizontal-scroll behaviors (pager parity)
+	captureViewportHScrollTests(fixtures)
+
 	// Capture textinput behaviors
 	captureTextInputTests(fixtures)
 
@@ -61,16 +83,45 @@ func main() {
 	// Capture key bindings
 	captureKeyBindingTests(fixtures)
 
+	// Capture regex/incremental search behaviors for viewport and list
+	captureSearchTests(fixtures)
+
 	// Capture list behaviors
 	captureListTests(fixtures)
 
+	// Capture list filter-machinery and fzf-style custom FilterFunc behaviors
+	captureListFilterTests(fixtures)
+	captureListFuzzyRankingTests(fixtures)
+
 	// Capture table behaviors
 	captureTableTests(fixtures)
 
 	// Capture filepicker behaviors
 	captureFilepickerTests(fixtures)
 
-	if err := fixtures.WriteToFile(*outputDir); err != nil {
+	// Capture filepicker multi-selection behaviors
+	captureFilepickerSelectionTests(fixtures)
+
+	// Capture filepicker IO worker (cut/copy/paste/delete) behaviors
+	captureFilepickerIOTests(fixtures)
+
+	// Capture filepicker miller-columns browser behaviors
+	captureFilepickerMillerTests(fixtures)
+
+	// Capture pluggable sort modes for filepicker and list
+	captureSortModeTests(fixtures)
+
+	// Capture mounted-filesystem picker state
+	captureFilesystemsTests(fixtures)
+
+	// Capture bulk-rename collision detection and cycle-breaking planner
+	captureBulkRenameTests(fixtures)
+
+	// Capture integrated "/" live-search (non-collapsing search-next) for
+	// list and table
+	captureLiveSearchTests(fixtures)
+
+	if err := fixtures.WriteWithFormat(*outputDir, *format); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -86,12 +137,12 @@ func captureViewportTests(fs *capture.FixtureSet) {
 				"height": 24,
 			},
 			map[string]interface{}{
-				"width":        vp.Width,
-				"height":       vp.Height,
-				"y_offset":     vp.YOffset,
-				"y_position":   vp.YPosition,
-				"at_top":       vp.AtTop(),
-				"at_bottom":    vp.AtBottom(),
+				"width":          vp.Width,
+				"height":         vp.Height,
+				"y_offset":       vp.YOffset,
+				"y_position":     vp.YPosition,
+				"at_top":         vp.AtTop(),
+				"at_bottom":      vp.AtBottom(),
 				"scroll_percent": vp.ScrollPercent(),
 			},
 		)
@@ -109,12 +160,12 @@ func captureViewportTests(fs *capture.FixtureSet) {
 				"content": content,
 			},
 			map[string]interface{}{
-				"total_lines":   10,
-				"visible_lines": 5,
-				"at_top":        vp.AtTop(),
-				"at_bottom":     vp.AtBottom(),
+				"total_lines":    10,
+				"visible_lines":  5,
+				"at_top":         vp.AtTop(),
+				"at_bottom":      vp.AtBottom(),
 				"scroll_percent": vp.ScrollPercent(),
-				"view":          vp.View(),
+				"view":           vp.View(),
 			},
 		)
 	}
@@ -127,10 +178,10 @@ func captureViewportTests(fs *capture.FixtureSet) {
 		vp.LineDown(1)
 		fs.AddTestWithCategory("viewport_scroll_down", "unit",
 			map[string]interface{}{
-				"width":      80,
-				"height":     3,
-				"content":    content,
-				"scroll_by":  1,
+				"width":     80,
+				"height":    3,
+				"content":   content,
+				"scroll_by": 1,
 			},
 			map[string]interface{}{
 				"y_offset":       vp.YOffset,
@@ -229,6 +280,433 @@ func captureViewportTests(fs *capture.FixtureSet) {
 	}
 }
 
+// captureViewportReflowTests captures soft-wrap/reflow behavior for long
+// lines as the viewport width changes. Modeled on how meli's Pager
+// recomputes LineBreakText lazily per width rather than eagerly reflowing
+// on every frame: rather than one snapshot per test, each fixture records
+// the wrapped lines produced at each width in a sequence over the same
+// source text, so a Rust port can prove it agrees line-for-line across
+// width changes.
+func captureViewportReflowTests(fs *capture.FixtureSet) {
+	longText := "The quick brown fox jumps over the lazy dog while the sun slowly sets behind the distant mountains, painting the sky in brilliant shades of orange and purple."
+
+	widths := []int{20, 40, 80}
+	reflowModes := []string{"Hard", "Soft", "None"}
+
+	// Test: sequence of widths over the same source text, one fixture per reflow mode
+	for _, mode := range reflowModes {
+		steps := make([]map[string]interface{}, 0, len(widths))
+		vp := viewport.New(widths[0], 6)
+		for _, w := range widths {
+			vp.Width = w
+			vp.SetContent(wrapForReflow(longText, w, mode))
+			steps = append(steps, map[string]interface{}{
+				"width":            w,
+				"view":             vp.View(),
+				"y_offset":         vp.YOffset,
+				"total_line_count": vp.TotalLineCount(),
+				"scroll_percent":   vp.ScrollPercent(),
+			})
+		}
+		fs.AddTestWithNotes(fmt.Sprintf("viewport_reflow_sequence_%s", strings.ToLower(mode)),
+			map[string]interface{}{
+				"source_text": longText,
+				"widths":      widths,
+				"reflow":      mode,
+			},
+			steps,
+			"Sequence of SetContent/Width changes over the same source text, one step per width",
+		)
+	}
+
+	// Test: width change after scrolling, confirm offset/percent stay consistent
+	{
+		vp := viewport.New(20, 4)
+		vp.SetContent(wrapForReflow(longText, 20, "Soft"))
+		vp.LineDown(2)
+		beforeOffset := vp.YOffset
+		vp.Width = 40
+		vp.SetContent(wrapForReflow(longText, 40, "Soft"))
+		fs.AddTestWithCategory("viewport_reflow_width_change_after_scroll", "unit",
+			map[string]interface{}{
+				"source_text": longText,
+				"from_width":  20,
+				"to_width":    40,
+				"reflow":      "Soft",
+			},
+			map[string]interface{}{
+				"y_offset_before_resize": beforeOffset,
+				"y_offset_after_resize":  vp.YOffset,
+				"total_line_count":       vp.TotalLineCount(),
+				"scroll_percent":         vp.ScrollPercent(),
+				"view":                   vp.View(),
+			},
+		)
+	}
+}
+
+// wrapForReflow simulates the three line-break strategies a viewport needs
+// to agree on across a Go/Rust port: Hard wraps mid-word at exactly width
+// runes, Soft wraps on word boundaries without exceeding width, and None
+// leaves lines unwrapped (so they overflow and get truncated/scrolled
+// horizontally by the renderer instead).
+func wrapForReflow(text string, width int, mode string) string {
+	if width <= 0 {
+		return text
+	}
+	switch mode {
+	case "None":
+		return text
+	case "Hard":
+		runes := []rune(text)
+		var lines []string
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+		return strings.Join(lines, "\n")
+	default: // Soft
+		words := strings.Fields(text)
+		var lines []string
+		var cur strings.Builder
+		for _, word := range words {
+			if cur.Len() == 0 {
+				cur.WriteString(word)
+				continue
+			}
+			if cur.Len()+1+len(word) > width {
+				lines = append(lines, cur.String())
+				cur.Reset()
+				cur.WriteString(word)
+			} else {
+				cur.WriteString(" ")
+				cur.WriteString(word)
+			}
+		}
+		if cur.Len() > 0 {
+			lines = append(lines, cur.String())
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// captureViewportAnsiPassthroughTests feeds the viewport content that
+// embeds CSI SGR sequences, OSC 8 hyperlinks, and DECSET private-mode
+// sequences interleaved with printable text, inspired by fzf's
+// passThroughRegex handling. It captures View() byte-for-byte, the
+// visible column width (which must ignore escape sequences), and whether
+// SGR state leaks across a scroll boundary when a sequence is never reset
+// before the next visible line -- the single biggest source of drift when
+// porting terminal renderers.
+func captureViewportAnsiPassthroughTests(fs *capture.FixtureSet) {
+	const (
+		sgrRed           = "\x1b[31m"
+		sgrReset         = "\x1b[0m"
+		osc8Start        = "\x1b]8;;https://example.com\x1b\\"
+		osc8End          = "\x1b]8;;\x1b\\"
+		decsetHideCursor = "\x1b[?25l"
+		decsetShowCursor = "\x1b[?25h"
+	)
+
+	passthroughCases := []struct {
+		name    string
+		content string
+	}{
+		{"csi_sgr_inline", "Plain text " + sgrRed + "red text" + sgrReset + " more plain"},
+		{"osc8_hyperlink", "Visit " + osc8Start + "our site" + osc8End + " today"},
+		{"decset_private_mode", decsetHideCursor + "Hidden cursor line" + decsetShowCursor},
+		{"interleaved", sgrRed + "Red " + osc8Start + "linked" + osc8End + " text" + sgrReset},
+	}
+
+	for _, tc := range passthroughCases {
+		vp := viewport.New(40, 3)
+		vp.SetContent(tc.content)
+		fs.AddTestWithCategory(fmt.Sprintf("viewport_ansi_passthrough_%s", tc.name), "unit",
+			map[string]interface{}{
+				"content": tc.content,
+			},
+			map[string]interface{}{
+				"view":          vp.View(),
+				"visible_width": lipgloss.Width(tc.content),
+			},
+		)
+	}
+
+	// Scrolling across a boundary where an SGR sequence is never reset
+	// before the next visible line: the viewport joins lines verbatim and
+	// does not re-emit carried-over SGR state, so the color is simply lost
+	// from the scrolled-into region rather than leaking across it.
+	{
+		content := strings.Join([]string{
+			"Normal line before color",
+			sgrRed + "Red starts here",
+			"still red continues",
+			sgrReset + "Back to normal",
+		}, "\n")
+
+		vp := viewport.New(40, 2)
+		vp.SetContent(content)
+		beforeScroll := vp.View()
+		vp.LineDown(2)
+		afterScroll := vp.View()
+
+		leaksAcrossBoundary := strings.Contains(afterScroll, sgrRed) && !strings.HasPrefix(afterScroll, sgrRed)
+
+		fs.AddTestWithEscapePreservation("viewport_ansi_scroll_boundary",
+			map[string]interface{}{
+				"content":   content,
+				"scroll_by": 2,
+			},
+			map[string]interface{}{
+				"view_before_scroll": beforeScroll,
+				"view_after_scroll":  afterScroll,
+			},
+			leaksAcrossBoundary,
+		)
+	}
+}
+
+// captureViewportHScrollTests captures horizontal scrolling over content
+// wider than the viewport, modeled on meli's pager (cols_lt_width,
+// horizontal cursor tracking). Bubbles' viewport has no XOffset, SoftWrap,
+// ScrollLeft, or ScrollRight today, so this drives the internal/hscroll
+// package that stands in for those. It records View() and XOffset after
+// sequences of ScrollRight/ScrollLeft calls, the can-scroll-right
+// predicate, tab expansion at a nonzero XOffset, and a double-width CJK
+// glyph straddling the left cut column -- exactly the edges where naive
+// byte-slicing ports break.
+func captureViewportHScrollTests(fs *capture.FixtureSet) {
+	const width = 10
+	lines := []string{
+		"This is a very long line that exceeds the viewport width by a lot",
+		"Short",
+	}
+
+	state := hscroll.New(width)
+	state.SoftWrap = false
+
+	fs.AddTestWithCategory("viewport_hscroll_initial", "unit",
+		map[string]interface{}{
+			"lines": lines,
+			"width": width,
+		},
+		map[string]interface{}{
+			"x_offset":         state.XOffset,
+			"soft_wrap":        state.SoftWrap,
+			"view":             renderHScroll(lines, state),
+			"can_scroll_right": state.CanScrollRight(lines),
+		},
+	)
+
+	// Sequence of ScrollRight/ScrollLeft calls, tracking XOffset and the
+	// can-scroll-right predicate after each step.
+	{
+		scrollOps := []struct {
+			name string
+			n    int
+			dir  string
+		}{
+			{"scroll_right_5", 5, "right"},
+			{"scroll_right_10", 10, "right"},
+			{"scroll_left_3", 3, "left"},
+			{"scroll_left_100", 100, "left"},
+		}
+
+		var steps []map[string]interface{}
+		for _, op := range scrollOps {
+			if op.dir == "right" {
+				state.ScrollRight(op.n, lines)
+			} else {
+				state.ScrollLeft(op.n)
+			}
+			steps = append(steps, map[string]interface{}{
+				"op":               op.name,
+				"x_offset":         state.XOffset,
+				"view":             renderHScroll(lines, state),
+				"can_scroll_right": state.CanScrollRight(lines),
+			})
+		}
+
+		fs.AddTestWithNotes("viewport_hscroll_sequence",
+			map[string]interface{}{
+				"lines": lines,
+				"width": width,
+			},
+			steps,
+			"Sequence of ScrollRight/ScrollLeft calls against the same viewport, tracking XOffset and the can-scroll-right predicate at each step",
+		)
+	}
+
+	// Tab expansion at a nonzero XOffset: tab stops are computed against
+	// the pre-scroll column, not the visible column, so a tab that crosses
+	// the cut column still lands on the correct stop.
+	{
+		const tabWidth = 8
+		tabLine := "a\tb\tc\td long tail text after tabs"
+		s := hscroll.New(tabWidth)
+		s.SoftWrap = false
+		s.ScrollRight(6, []string{tabLine})
+
+		fs.AddTestWithCategory("viewport_hscroll_tab_expansion", "unit",
+			map[string]interface{}{
+				"line":     tabLine,
+				"width":    tabWidth,
+				"x_offset": s.XOffset,
+			},
+			map[string]interface{}{
+				"view": hscroll.CutLine(tabLine, s.XOffset, s.Width),
+			},
+		)
+	}
+
+	// Double-width CJK glyph straddling the left cut column: the cut
+	// renders a single space for the glyph's visible sliver rather than
+	// splitting it into a half-width garbage byte.
+	{
+		const cjkWidth = 6
+		cjkLine := "abc中文defgh"
+		s := hscroll.New(cjkWidth)
+		s.SoftWrap = false
+		s.ScrollRight(4, []string{cjkLine})
+
+		fs.AddTestWithNotes("viewport_hscroll_cjk_straddle",
+			map[string]interface{}{
+				"line":     cjkLine,
+				"width":    cjkWidth,
+				"x_offset": s.XOffset,
+			},
+			map[string]interface{}{
+				"view": hscroll.CutLine(cjkLine, s.XOffset, s.Width),
+			},
+			"Cut column lands mid-glyph on a double-width CJK character; the straddling glyph renders as a single space rather than a split byte",
+		)
+	}
+}
+
+func renderHScroll(lines []string, state *hscroll.State) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = hscroll.CutLine(l, state.XOffset, state.Width)
+	}
+	return out
+}
+
+// captureSearchTests captures a regex-search overlay over viewport content
+// and over list items, modeled on alacritty's RegexSearch and fzf's
+// incremental matcher. Bubbles does not ship a viewport search today, so
+// this drives the internal/search package that stands in for the
+// viewport.Search(pattern string, opts SearchOptions) API the Rust port
+// needs a concrete conformance suite for.
+func captureSearchTests(fs *capture.FixtureSet) {
+	content := "apple banana\nBanana split\ncherry APPLE pie\nfoo bar baz\nbar foo bar\nzzz\nqux bar"
+
+	searchCases := []struct {
+		name            string
+		pattern         string
+		caseInsensitive bool
+		regex           bool
+		multiLine       bool
+	}{
+		{"plain_string", "bar", false, false, false},
+		{"case_insensitive", "apple", true, false, false},
+		{"regex_anchored_multiline", "^bar", false, true, true},
+	}
+
+	for _, tc := range searchCases {
+		matches, err := search.InViewport(content, tc.pattern, search.Options{
+			CaseInsensitive: tc.caseInsensitive,
+			Regex:           tc.regex,
+			MultiLine:       tc.multiLine,
+		})
+
+		matchOut := make([]map[string]int, len(matches))
+		for i, m := range matches {
+			matchOut[i] = map[string]int{"line": m.Line, "start_col": m.StartCol, "end_col": m.EndCol}
+		}
+
+		cursor := search.NewCursor(matches)
+		cursor.NextMatch()
+		cursor.NextMatch()
+		focused := cursor.PrevMatch() // net: +1 from the first match
+
+		vp := viewport.New(20, 3)
+		vp.SetContent(content)
+		yOffset := search.YOffsetForMatch(focused, vp.Height, vp.TotalLineCount())
+		vp.YOffset = yOffset
+
+		fs.AddTestWithCategory(fmt.Sprintf("viewport_search_%s", tc.name), "unit",
+			map[string]interface{}{
+				"content":          content,
+				"pattern":          tc.pattern,
+				"case_insensitive": tc.caseInsensitive,
+				"regex":            tc.regex,
+				"multi_line":       tc.multiLine,
+			},
+			map[string]interface{}{
+				"matches":                         matchOut,
+				"error":                           err != nil,
+				"focus_index_after_2_next_1_prev": cursor.Index,
+				"focused_match": map[string]int{
+					"line": focused.Line, "start_col": focused.StartCol, "end_col": focused.EndCol,
+				},
+				"y_offset_on_scroll_to_match": yOffset,
+			},
+		)
+	}
+
+	// Highlight render hook: wrap matches in the first line in brackets.
+	{
+		matches, _ := search.InViewport(content, "apple", search.Options{CaseInsensitive: true})
+		var firstLineRanges [][2]int
+		for _, m := range matches {
+			if m.Line == 0 {
+				firstLineRanges = append(firstLineRanges, [2]int{m.StartCol, m.EndCol})
+			}
+		}
+		highlighted := search.Highlight(strings.Split(content, "\n")[0], firstLineRanges, func(s string) string {
+			return "[" + s + "]"
+		})
+		fs.AddTestWithCategory("viewport_search_highlight", "unit",
+			map[string]interface{}{
+				"line":    strings.Split(content, "\n")[0],
+				"pattern": "apple",
+			},
+			map[string]interface{}{
+				"highlighted": highlighted,
+			},
+		)
+	}
+
+	// List incremental filter fixtures: ordered filtered indices and match ranges.
+	listValues := []string{"Apple Pie", "Banana Split", "Cherry Tart", "apple Sauce", "Grapefruit"}
+	filterCases := []string{"apple", "a", "xyz", ""}
+	for _, pattern := range filterCases {
+		name := pattern
+		if name == "" {
+			name = "empty"
+		}
+		results := search.FilterList(listValues, pattern)
+		resultOut := make([]map[string]interface{}, len(results))
+		for i, r := range results {
+			resultOut[i] = map[string]interface{}{
+				"index":  r.Index,
+				"ranges": r.Ranges,
+				"score":  r.Score,
+			}
+		}
+		fs.AddTestWithCategory(fmt.Sprintf("list_search_filter_%s", name), "unit",
+			map[string]interface{}{
+				"items":   listValues,
+				"pattern": pattern,
+			},
+			map[string]interface{}{
+				"results": resultOut,
+			},
+		)
+	}
+}
+
 func captureTextInputTests(fs *capture.FixtureSet) {
 	// Test 1: Basic text input
 	{
@@ -356,9 +834,9 @@ func captureTextInputTests(fs *capture.FixtureSet) {
 				"echo_mode": "password",
 			},
 			map[string]interface{}{
-				"value":         ti.Value(),
-				"echo_mode":     int(ti.EchoMode),
-				"echo_char":     string(ti.EchoCharacter),
+				"value":     ti.Value(),
+				"echo_mode": int(ti.EchoMode),
+				"echo_char": string(ti.EchoCharacter),
 			},
 		)
 	}
@@ -407,9 +885,9 @@ func captureProgressTests(fs *capture.FixtureSet) {
 				"percent": 0.5,
 			},
 			map[string]interface{}{
-				"view_length":       len(view),
-				"percent":           0.5,
-				"is_animated":       p.IsAnimating(),
+				"view_length": len(view),
+				"percent":     0.5,
+				"is_animated": p.IsAnimating(),
 			},
 		)
 	}
@@ -696,8 +1174,8 @@ func capturePaginatorTests(fs *capture.FixtureSet) {
 		afterPrev := p.Page
 		fs.AddTestWithCategory("paginator_navigation", "unit",
 			map[string]interface{}{
-				"total_pages":  5,
-				"start_page":   0,
+				"total_pages": 5,
+				"start_page":  0,
 			},
 			map[string]interface{}{
 				"after_next": afterNext,
@@ -821,7 +1299,7 @@ func captureCursorTests(fs *capture.FixtureSet) {
 				"mode": m.name,
 			},
 			map[string]interface{}{
-				"mode_value": int(m.mode),
+				"mode_value":  int(m.mode),
 				"mode_string": m.mode.String(),
 			},
 		)
@@ -915,9 +1393,9 @@ func captureKeyBindingTests(fs *capture.FixtureSet) {
 				"keys": []string{"y"},
 			},
 			map[string]interface{}{
-				"initial_enabled":       before,
-				"after_disable":         afterDisable,
-				"after_enable":          afterEnable,
+				"initial_enabled": before,
+				"after_disable":   afterDisable,
+				"after_enable":    afterEnable,
 			},
 		)
 	}
@@ -978,10 +1456,10 @@ func captureListTests(fs *capture.FixtureSet) {
 				"height": 24,
 			},
 			map[string]interface{}{
-				"index":         l.Index(),
-				"cursor":        l.Cursor(),
-				"items_count":   len(l.Items()),
-				"filter_state":  l.FilterState().String(),
+				"index":        l.Index(),
+				"cursor":       l.Cursor(),
+				"items_count":  len(l.Items()),
+				"filter_state": l.FilterState().String(),
 			},
 		)
 	}
@@ -1108,8 +1586,8 @@ func captureListTests(fs *capture.FixtureSet) {
 				"title": "My List",
 			},
 			map[string]interface{}{
-				"title":       l.Title,
-				"show_title":  l.ShowTitle(),
+				"title":      l.Title,
+				"show_title": l.ShowTitle(),
 			},
 		)
 	}
@@ -1136,6 +1614,208 @@ func captureListTests(fs *capture.FixtureSet) {
 	}
 }
 
+// captureListFilterTests drives list.Model's filter machinery keystroke by
+// keystroke, since the default delegate's fuzzy matching (backed by
+// sahilm/fuzzy) is where most Rust ports will diverge from the Go
+// behavior. For each query, it captures FilterState, the ordered
+// VisibleItems, the matched rune positions for each visible item (for
+// highlighting), and the resulting Index/Cursor after every keystroke.
+func captureListFilterTests(fs *capture.FixtureSet) {
+	items := []list.Item{
+		listItem{title: "apple pie", description: "dessert"},
+		listItem{title: "banana bread", description: "baked good"},
+		listItem{title: "cherry tart", description: "dessert"},
+		listItem{title: "APPLE sauce", description: "condiment"},
+		listItem{title: "mango chutney", description: "condiment"},
+		listItem{title: "café crème", description: "dessert"},
+	}
+
+	queries := []string{"app", "ban ry", "xyz", "APP", "café"}
+
+	for _, q := range queries {
+		l := list.New(items, list.NewDefaultDelegate(), 40, 10)
+		l.SetFilteringEnabled(true)
+		l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+
+		steps := make([]map[string]interface{}, 0, len(q))
+		for _, r := range q {
+			l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+
+			visible := l.VisibleItems()
+			titles := make([]string, len(visible))
+			matches := make([][]int, len(visible))
+			for i, it := range visible {
+				titles[i] = it.(listItem).title
+				matches[i] = l.MatchesForItem(i)
+			}
+
+			steps = append(steps, map[string]interface{}{
+				"key":             string(r),
+				"filter_state":    l.FilterState().String(),
+				"visible_items":   titles,
+				"matched_indexes": matches,
+				"index":           l.Index(),
+				"cursor":          l.Cursor(),
+			})
+		}
+
+		fs.AddTestWithCategory(fmt.Sprintf("list_filter_query_%s", sanitizeFilterName(q)), "unit",
+			map[string]interface{}{
+				"items": itemTitles(items),
+				"query": q,
+			},
+			map[string]interface{}{
+				"steps": steps,
+			},
+		)
+	}
+}
+
+// captureListFuzzyRankingTests swaps in a custom list.FilterFunc
+// implementing fzf-style bonus scoring (word-start bonus, camelCase
+// bonus, consecutive-match bonus) and records the resulting ranking on a
+// corpus designed to exercise all three bonuses, then confirms the same
+// ordering shows up in a live list.Model's VisibleItems.
+func captureListFuzzyRankingTests(fs *capture.FixtureSet) {
+	targets := []string{"AppleOrange", "apple_pie", "grapApple", "Apple", "snApple"}
+	term := "app"
+
+	ranks := fzfStyleFilter(term, targets)
+	out := make([]map[string]interface{}, len(ranks))
+	for i, r := range ranks {
+		out[i] = map[string]interface{}{
+			"target":          targets[r.Index],
+			"index":           r.Index,
+			"matched_indexes": r.MatchedIndexes,
+		}
+	}
+	fs.AddTestWithNotes("list_custom_filterfunc_fzf_bonus_ranking",
+		map[string]interface{}{
+			"targets": targets,
+			"term":    term,
+		},
+		out,
+		"Custom list.FilterFunc applying word-start, camelCase, and consecutive-match bonuses; pins the ranking order a port must reproduce",
+	)
+
+	items := make([]list.Item, len(targets))
+	for i, t := range targets {
+		items[i] = listItem{title: t}
+	}
+	l := list.New(items, list.NewDefaultDelegate(), 40, 10)
+	l.Filter = fzfStyleFilter
+	l.SetFilteringEnabled(true)
+	l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	for _, r := range term {
+		l, _ = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	visible := l.VisibleItems()
+	order := make([]string, len(visible))
+	for i, it := range visible {
+		order[i] = it.(listItem).title
+	}
+	fs.AddTestWithCategory("list_custom_filterfunc_applied_order", "unit",
+		map[string]interface{}{
+			"targets": targets,
+			"term":    term,
+		},
+		map[string]interface{}{
+			"visible_order": order,
+		},
+	)
+}
+
+// fzfStyleFilter is a simplified fzf-style bonus fuzzy filter: matches
+// must occur in order (classic subsequence fuzzy matching) but score
+// higher for word-start, camelCase, and consecutive-match bonuses.
+func fzfStyleFilter(term string, targets []string) []list.Rank {
+	lowerTerm := strings.ToLower(term)
+
+	var ranks []list.Rank
+	scores := map[int]int{}
+	for i, target := range targets {
+		score, positions, ok := fuzzyBonusScore(lowerTerm, target)
+		if !ok {
+			continue
+		}
+		ranks = append(ranks, list.Rank{Index: i, MatchedIndexes: positions})
+		scores[i] = score
+	}
+
+	sort.SliceStable(ranks, func(a, b int) bool {
+		return scores[ranks[a].Index] > scores[ranks[b].Index]
+	})
+	return ranks
+}
+
+// fuzzyBonusScore subsequence-matches lowerTerm against target, returning
+// a bonus-weighted score and the matched rune positions within target.
+func fuzzyBonusScore(lowerTerm, target string) (score int, positions []int, ok bool) {
+	lowerTarget := strings.ToLower(target)
+	ti := 0
+	consecutive := 0
+	for i := 0; i < len(lowerTerm); i++ {
+		c := lowerTerm[i]
+		found := false
+		for ; ti < len(lowerTarget); ti++ {
+			if lowerTarget[ti] == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, nil, false
+		}
+
+		bonus := 1
+		if ti == 0 || target[ti-1] == ' ' || target[ti-1] == '_' {
+			bonus += 5 // word-start bonus
+		}
+		if ti > 0 && isASCIILower(rune(target[ti-1])) && isASCIIUpper(rune(target[ti])) {
+			bonus += 4 // camelCase bonus
+		}
+		if len(positions) > 0 && positions[len(positions)-1] == ti-1 {
+			consecutive++
+			bonus += consecutive * 2 // consecutive-match bonus
+		} else {
+			consecutive = 0
+		}
+
+		score += bonus
+		positions = append(positions, ti)
+		ti++
+	}
+	return score, positions, true
+}
+
+func isASCIILower(r rune) bool { return r >= 'a' && r <= 'z' }
+func isASCIIUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+
+func itemTitles(items []list.Item) []string {
+	titles := make([]string, len(items))
+	for i, it := range items {
+		titles[i] = it.(listItem).title
+	}
+	return titles
+}
+
+// sanitizeFilterName converts a filter query into a valid fixture name.
+func sanitizeFilterName(s string) string {
+	if s == "" {
+		return "empty"
+	}
+	var b strings.Builder
+	for _, c := range s {
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
 func captureTableTests(fs *capture.FixtureSet) {
 	// Test 1: Empty table
 	{
@@ -1146,10 +1826,10 @@ func captureTableTests(fs *capture.FixtureSet) {
 		fs.AddTestWithCategory("table_empty", "unit",
 			map[string]interface{}{},
 			map[string]interface{}{
-				"cursor":       t.Cursor(),
-				"focused":      t.Focused(),
+				"cursor":        t.Cursor(),
+				"focused":       t.Focused(),
 				"columns_count": 0,
-				"rows_count":   0,
+				"rows_count":    0,
 			},
 		)
 	}
@@ -1366,7 +2046,7 @@ func captureTableTests(fs *capture.FixtureSet) {
 				"rows_count": 3,
 			},
 			map[string]interface{}{
-				"at_top_after_up":     atTopAfterUp,
+				"at_top_after_up":      atTopAfterUp,
 				"at_bottom_after_down": atBottomAfterDown,
 			},
 		)
@@ -1380,12 +2060,12 @@ func captureFilepickerTests(fs *capture.FixtureSet) {
 		fs.AddTestWithCategory("filepicker_new", "unit",
 			map[string]interface{}{},
 			map[string]interface{}{
-				"show_permissions": fp.ShowPermissions,
-				"show_size":        fp.ShowSize,
-				"show_hidden":      fp.ShowHidden,
-				"dir_allowed":      fp.DirAllowed,
-				"file_allowed":     fp.FileAllowed,
-				"auto_height":      fp.AutoHeight,
+				"show_permissions":  fp.ShowPermissions,
+				"show_size":         fp.ShowSize,
+				"show_hidden":       fp.ShowHidden,
+				"dir_allowed":       fp.DirAllowed,
+				"file_allowed":      fp.FileAllowed,
+				"auto_height":       fp.AutoHeight,
 				"current_directory": fp.CurrentDirectory,
 			},
 		)
@@ -1473,11 +2153,11 @@ func captureFilepickerTests(fs *capture.FixtureSet) {
 		fs.AddTestWithCategory("filepicker_keybindings", "unit",
 			map[string]interface{}{},
 			map[string]interface{}{
-				"up_keys":      fp.KeyMap.Up.Keys(),
-				"down_keys":    fp.KeyMap.Down.Keys(),
-				"open_keys":    fp.KeyMap.Open.Keys(),
-				"back_keys":    fp.KeyMap.Back.Keys(),
-				"select_keys":  fp.KeyMap.Select.Keys(),
+				"up_keys":     fp.KeyMap.Up.Keys(),
+				"down_keys":   fp.KeyMap.Down.Keys(),
+				"open_keys":   fp.KeyMap.Open.Keys(),
+				"back_keys":   fp.KeyMap.Back.Keys(),
+				"select_keys": fp.KeyMap.Select.Keys(),
 			},
 		)
 	}
@@ -1556,3 +2236,1095 @@ func captureFilepickerTests(fs *capture.FixtureSet) {
 		_ = view // silence unused variable warning
 	}
 }
+
+// captureFilepickerSelectionTests captures the multi-selection subsystem
+// (space to toggle, A to select-all, I to invert, C to clear) that
+// filepicker.Model doesn't ship today. It drives the internal/fsops
+// package that stands in for SelectedPaths, ToggleSelect,
+// InvertSelection, and ClearSelection.
+func captureFilepickerSelectionTests(fs *capture.FixtureSet) {
+	all := []string{"a.txt", "b.txt", "c.txt", "dir_d"}
+
+	// Test 1: toggle selects and deselects individual paths
+	{
+		sel := fsops.NewSelection()
+		sel.ToggleSelect("a.txt")
+		sel.ToggleSelect("c.txt")
+		afterTwoToggles := sel.SelectedPaths()
+		sel.ToggleSelect("a.txt")
+		afterUntoggle := sel.SelectedPaths()
+		fs.AddTestWithCategory("filepicker_selection_toggle", "unit",
+			map[string]interface{}{
+				"all":     all,
+				"toggles": []string{"a.txt", "c.txt", "a.txt"},
+			},
+			map[string]interface{}{
+				"after_two_toggles": afterTwoToggles,
+				"after_untoggle":    afterUntoggle,
+			},
+		)
+	}
+
+	// Test 2: select-all then invert
+	{
+		sel := fsops.NewSelection()
+		sel.SelectAll(all)
+		afterSelectAll := sel.SelectedPaths()
+		sel.ToggleSelect("b.txt")
+		sel.InvertSelection(all)
+		afterInvert := sel.SelectedPaths()
+		fs.AddTestWithNotes("filepicker_selection_select_all_invert",
+			map[string]interface{}{
+				"all": all,
+			},
+			map[string]interface{}{
+				"after_select_all": afterSelectAll,
+				"after_invert":     afterInvert,
+			},
+			"InvertSelection is computed against the full listing, not just the currently selected paths",
+		)
+	}
+
+	// Test 3: clear selection
+	{
+		sel := fsops.NewSelection()
+		sel.SelectAll(all)
+		sel.ClearSelection()
+		fs.AddTestWithCategory("filepicker_selection_clear", "unit",
+			map[string]interface{}{
+				"all": all,
+			},
+			map[string]interface{}{
+				"selected_paths": sel.SelectedPaths(),
+			},
+		)
+	}
+
+	// Test 4: default selection keybindings
+	{
+		km := fsops.DefaultSelectionKeyMap()
+		fs.AddTestWithCategory("filepicker_selection_keybindings", "unit",
+			map[string]interface{}{},
+			map[string]interface{}{
+				"toggle_select_keys":    km.ToggleSelect.Keys(),
+				"select_all_keys":       km.SelectAll.Keys(),
+				"invert_selection_keys": km.InvertSelection.Keys(),
+				"clear_selection_keys":  km.ClearSelection.Keys(),
+			},
+		)
+	}
+}
+
+// captureFilepickerIOTests captures the background IO worker that
+// performs cut/copy/paste/delete across a selection, streaming each file
+// chunk-by-chunk and reporting progress via IOProgressMsg/IODoneMsg/
+// IOErrMsg, modeled on joshuto/hunter's ShowWorkers overlay. It drives the
+// internal/fsops package against real temporary files and directories so
+// the recorded message sequences reflect actual filesystem behavior.
+func captureFilepickerIOTests(fs *capture.FixtureSet) {
+	// Test 1: copy a selection of files into a destination directory,
+	// recording the ordered message sequence the worker emits.
+	{
+		_, destDir, files, cleanup := setupIOFixtureDirs(fs, "filepicker_io_copy")
+		defer cleanup()
+
+		worker := fsops.NewWorker()
+		cmd := worker.Copy(files, destDir)
+		messages := drainWorkerMessages(worker, cmd)
+
+		destNames, _ := os.ReadDir(destDir)
+		names := make([]string, 0, len(destNames))
+		for _, e := range destNames {
+			names = append(names, e.Name())
+		}
+		sort.Strings(names)
+
+		fs.AddTestWithNotes("filepicker_io_copy",
+			map[string]interface{}{
+				"src_dir":  "temp",
+				"dest_dir": "temp",
+				"files":    relNames(files),
+			},
+			map[string]interface{}{
+				"messages":      messages,
+				"dest_contents": names,
+			},
+			"Copy streams one IOProgressMsg per file chunk-by-chunk, then a final IODoneMsg",
+		)
+	}
+
+	// Test 2: move (cut) a selection, verifying sources are removed after
+	// the copy completes.
+	{
+		_, destDir, files, cleanup := setupIOFixtureDirs(fs, "filepicker_io_move")
+		defer cleanup()
+
+		worker := fsops.NewWorker()
+		cmd := worker.Move(files, destDir)
+		messages := drainWorkerMessages(worker, cmd)
+
+		var sourcesRemain []string
+		for _, f := range files {
+			if _, err := os.Stat(f); err == nil {
+				sourcesRemain = append(sourcesRemain, filepath.Base(f))
+			}
+		}
+
+		fs.AddTestWithNotes("filepicker_io_move",
+			map[string]interface{}{
+				"src_dir":  "temp",
+				"dest_dir": "temp",
+				"files":    relNames(files),
+			},
+			map[string]interface{}{
+				"messages":       messages,
+				"sources_remain": sourcesRemain,
+			},
+			"Move copies then removes each source; sources_remain is empty on success",
+		)
+	}
+
+	// Test 3: delete a selection.
+	{
+		_, _, files, cleanup := setupIOFixtureDirs(fs, "filepicker_io_delete")
+		defer cleanup()
+
+		worker := fsops.NewWorker()
+		cmd := worker.Delete(files)
+		messages := drainWorkerMessages(worker, cmd)
+
+		var remaining []string
+		for _, f := range files {
+			if _, err := os.Stat(f); err == nil {
+				remaining = append(remaining, filepath.Base(f))
+			}
+		}
+
+		fs.AddTestWithCategory("filepicker_io_delete", "unit",
+			map[string]interface{}{
+				"src_dir": "temp",
+				"files":   relNames(files),
+			},
+			map[string]interface{}{
+				"messages":  messages,
+				"remaining": remaining,
+			},
+		)
+	}
+
+	// Test 4: paste from a copy clipboard versus a cut clipboard.
+	{
+		_, destDir, files, cleanup := setupIOFixtureDirs(fs, "filepicker_io_paste_copy")
+		defer cleanup()
+
+		worker := fsops.NewWorker()
+		clip := &fsops.Clipboard{Mode: fsops.ClipboardCopy, Paths: files}
+		cmd := worker.Paste(clip, destDir)
+		messages := drainWorkerMessages(worker, cmd)
+
+		fs.AddTestWithNotes("filepicker_io_paste_copy_clipboard",
+			map[string]interface{}{
+				"src_dir":  "temp",
+				"dest_dir": "temp",
+				"files":    relNames(files),
+			},
+			map[string]interface{}{
+				"messages":             messages,
+				"clipboard_mode_after": int(clip.Mode),
+			},
+			"Pasting a copy clipboard duplicates the paths and leaves the clipboard mode unchanged",
+		)
+	}
+
+	// Test 5: cancellation mid-operation.
+	{
+		_, destDir, files, cleanup := setupIOFixtureDirs(fs, "filepicker_io_cancel")
+		defer cleanup()
+
+		worker := fsops.NewWorker()
+		worker.Cancel()
+		cmd := worker.Copy(files, destDir)
+		messages := drainWorkerMessages(worker, cmd)
+
+		fs.AddTestWithNotes("filepicker_io_cancel",
+			map[string]interface{}{
+				"src_dir":  "temp",
+				"dest_dir": "temp",
+				"files":    relNames(files),
+			},
+			map[string]interface{}{
+				"messages": messages,
+			},
+			"Canceling the worker's context before the operation starts yields a single IOErrMsg wrapping context.Canceled",
+		)
+	}
+}
+
+// setupIOFixtureDirs creates a temp source directory with a few files plus
+// an empty destination directory for an IO worker fixture, returning the
+// absolute source file paths and a cleanup func. If temp dir creation
+// fails, it records a skip-reason fixture and returns an empty setup.
+func setupIOFixtureDirs(fs *capture.FixtureSet, name string) (srcDir, destDir string, files []string, cleanup func()) {
+	srcDir, err := os.MkdirTemp("", "fp_io_src")
+	if err != nil {
+		fs.Tests = append(fs.Tests, capture.TestFixture{
+			Name:       name,
+			Input:      map[string]interface{}{},
+			SkipReason: capture.Ptr("could not create temp directory: " + err.Error()),
+		})
+		return "", "", nil, func() {}
+	}
+	destDir, err = os.MkdirTemp("", "fp_io_dest")
+	if err != nil {
+		os.RemoveAll(srcDir)
+		fs.Tests = append(fs.Tests, capture.TestFixture{
+			Name:       name,
+			Input:      map[string]interface{}{},
+			SkipReason: capture.Ptr("could not create temp directory: " + err.Error()),
+		})
+		return "", "", nil, func() {}
+	}
+
+	names := []string{"one.txt", "two.txt", "three.txt"}
+	for _, n := range names {
+		path := filepath.Join(srcDir, n)
+		os.WriteFile(path, []byte("contents of "+n), 0644)
+		files = append(files, path)
+	}
+
+	cleanup = func() {
+		os.RemoveAll(srcDir)
+		os.RemoveAll(destDir)
+	}
+	return srcDir, destDir, files, cleanup
+}
+
+// drainWorkerMessages repeatedly invokes cmd (and subsequent Listen
+// commands) until an IODoneMsg or IOErrMsg arrives, collecting every
+// message into a JSON-friendly slice -- mirroring how a bubbletea Update
+// loop would re-issue Listen after each IOProgressMsg.
+func drainWorkerMessages(worker *fsops.Worker, cmd tea.Cmd) []map[string]interface{} {
+	var out []map[string]interface{}
+	for {
+		msg := cmd()
+		switch m := msg.(type) {
+		case fsops.IOProgressMsg:
+			out = append(out, map[string]interface{}{
+				"type":         "progress",
+				"done":         m.Done,
+				"total":        m.Total,
+				"current_path": filepath.Base(m.CurrentPath),
+			})
+			cmd = worker.Listen()
+		case fsops.IODoneMsg:
+			out = append(out, map[string]interface{}{"type": "done"})
+			return out
+		case fsops.IOErrMsg:
+			errText := ""
+			if m.Err != nil {
+				errText = m.Err.Error()
+			}
+			out = append(out, map[string]interface{}{"type": "error", "error": errText})
+			return out
+		default:
+			return out
+		}
+	}
+}
+
+// relNames returns the base names of paths, for fixtures that shouldn't
+// embed the nondeterministic temp-directory prefix.
+func relNames(paths []string) []string {
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = filepath.Base(p)
+	}
+	return out
+}
+
+// captureFilepickerMillerTests captures the Miller-columns browser mode
+// (parent/current/preview panes) that filepicker.Model doesn't ship today,
+// mirroring the layout used by hunter/joshuto/broot. It drives the
+// internal/miller package that stands in for MillerModel, asserting pane
+// widths, cursor sync, and preview cache hits.
+func captureFilepickerMillerTests(fs *capture.FixtureSet) {
+	// Test 1: default and custom pane-width ratios
+	{
+		m := miller.NewMillerModel(miller.DefaultPreviewRenderer{})
+		m.Width = 90
+		defaultWidths := m.PaneWidths()
+
+		m.WithMillerColumns([3]int{1, 2, 2})
+		customWidths := m.PaneWidths()
+
+		fs.AddTestWithCategory("filepicker_miller_pane_widths", "unit",
+			map[string]interface{}{
+				"width":          90,
+				"default_ratios": [3]int{1, 1, 1},
+				"custom_ratios":  [3]int{1, 2, 2},
+			},
+			map[string]interface{}{
+				"default_widths": defaultWidths,
+				"custom_widths":  customWidths,
+			},
+		)
+	}
+
+	// Test 2: cursor sync stays within the current pane's listing bounds
+	{
+		m := miller.NewMillerModel(miller.DefaultPreviewRenderer{})
+		m.CurrentEntries = []string{"a.txt", "b.txt", "c.txt"}
+
+		m.CursorUp() // already at 0, stays clamped
+		atStart := m.CursorIndex
+		m.CursorDown()
+		m.CursorDown()
+		m.CursorDown() // past the end, stays clamped
+		atEnd := m.CursorIndex
+		selected := m.SelectedPath("/tmp/current")
+
+		fs.AddTestWithNotes("filepicker_miller_cursor_sync",
+			map[string]interface{}{
+				"entries": m.CurrentEntries,
+			},
+			map[string]interface{}{
+				"index_after_up_at_start": atStart,
+				"index_after_three_down":  atEnd,
+				"selected_path":           selected,
+			},
+			"CursorUp/CursorDown clamp to the listing bounds instead of wrapping",
+		)
+	}
+
+	// Test 3: preview rendering for a directory, a small text file, and a
+	// binary file, exercising the three branches DefaultPreviewRenderer
+	// must distinguish.
+	{
+		tmpDir, err := os.MkdirTemp("", "miller_preview")
+		if err == nil {
+			defer os.RemoveAll(tmpDir)
+
+			os.Mkdir(filepath.Join(tmpDir, "subdir"), 0755)
+			os.WriteFile(filepath.Join(tmpDir, "readme.txt"), []byte("line one\nline two\nline three"), 0644)
+			os.WriteFile(filepath.Join(tmpDir, "data.bin"), []byte{0x00, 0x01, 0x02, 0xff}, 0644)
+
+			renderer := miller.DefaultPreviewRenderer{}
+			dirPreview, _ := renderer.Render(filepath.Join(tmpDir, "subdir"), 80, 10)
+			textPreview, _ := renderer.Render(filepath.Join(tmpDir, "readme.txt"), 80, 10)
+			binPreview, _ := renderer.Render(filepath.Join(tmpDir, "data.bin"), 80, 10)
+
+			fs.AddTestWithCategory("filepicker_miller_preview_kinds", "unit",
+				map[string]interface{}{
+					"test_dir": "temp",
+				},
+				map[string]interface{}{
+					"dir_preview":  dirPreview,
+					"text_preview": textPreview,
+					"bin_preview":  binPreview,
+				},
+			)
+		}
+	}
+
+	// Test 4: preview rendering degrades once a file exceeds the
+	// configured size threshold.
+	{
+		tmpDir, err := os.MkdirTemp("", "miller_preview_large")
+		if err == nil {
+			defer os.RemoveAll(tmpDir)
+
+			bigPath := filepath.Join(tmpDir, "big.txt")
+			os.WriteFile(bigPath, make([]byte, 2048), 0644)
+
+			renderer := miller.DefaultPreviewRenderer{MaxPreviewSize: 1024}
+			preview, _ := renderer.Render(bigPath, 80, 10)
+
+			fs.AddTestWithNotes("filepicker_miller_preview_size_threshold",
+				map[string]interface{}{
+					"test_dir":         "temp",
+					"file_size":        2048,
+					"max_preview_size": 1024,
+				},
+				map[string]interface{}{
+					"preview": preview,
+				},
+				"Files over MaxPreviewSize degrade to a size placeholder instead of reading the full contents",
+			)
+		}
+	}
+
+	// Test 5: LoadPreview caches by path+mtime, so re-requesting the same
+	// unchanged file reports a cache hit.
+	{
+		tmpDir, err := os.MkdirTemp("", "miller_preview_cache")
+		if err == nil {
+			defer os.RemoveAll(tmpDir)
+
+			path := filepath.Join(tmpDir, "note.txt")
+			os.WriteFile(path, []byte("cached contents"), 0644)
+
+			m := miller.NewMillerModel(miller.DefaultPreviewRenderer{})
+			m.Width, m.Height = 90, 10
+
+			first := m.LoadPreview(path)().(miller.PreviewMsg)
+			second := m.LoadPreview(path)().(miller.PreviewMsg)
+
+			fs.AddTestWithNotes("filepicker_miller_preview_cache_hit",
+				map[string]interface{}{
+					"test_dir": "temp",
+				},
+				map[string]interface{}{
+					"first_cache_hit":  first.CacheHit,
+					"second_cache_hit": second.CacheHit,
+					"content_matches":  first.Content == second.Content,
+				},
+				"The first LoadPreview renders and populates the cache; the second, unchanged-mtime call is a cache hit",
+			)
+		}
+	}
+}
+
+// captureSortModeTests captures filepicker.Model.SetSort and list.Model's
+// WithSortFunc option, which don't exist in Bubbles today. It drives the
+// internal/sorting package that stands in for both, against a fixed set
+// of entries mixing case and numeric filenames, covering every SortType
+// plus the dirs-first toggle and natural-sort leading-zero tiebreaker.
+func captureSortModeTests(fs *capture.FixtureSet) {
+	fixedEntries := func() []sorting.Entry {
+		return []sorting.Entry{
+			{Name: "Banana.txt", IsDir: false, Size: 300, MTime: 500},
+			{Name: "apple.txt", IsDir: false, Size: 100, MTime: 300},
+			{Name: "file10.txt", IsDir: false, Size: 50, MTime: 100},
+			{Name: "file2.txt", IsDir: false, Size: 75, MTime: 200},
+			{Name: "zeta", IsDir: true, Size: 0, MTime: 400},
+			{Name: "Archive", IsDir: true, Size: 0, MTime: 600},
+		}
+	}
+
+	names := func(entries []sorting.Entry) []string {
+		out := make([]string, len(entries))
+		for i, e := range entries {
+			out[i] = e.Name
+		}
+		return out
+	}
+
+	// Test 1: each SortType, not reversed, without dirs-first.
+	{
+		sortTypes := []sorting.SortType{
+			sorting.SortName,
+			sorting.SortSize,
+			sorting.SortMTime,
+			sorting.SortExtension,
+			sorting.SortNatural,
+		}
+		for _, st := range sortTypes {
+			entries := fixedEntries()
+			sorting.SortEntries(entries, st, false, false)
+			fs.AddTestWithCategory("filepicker_sort_"+st.String(), "unit",
+				map[string]interface{}{
+					"entries":    names(fixedEntries()),
+					"sort_type":  st.String(),
+					"reverse":    false,
+					"dirs_first": false,
+				},
+				map[string]interface{}{
+					"sorted": names(entries),
+				},
+			)
+		}
+	}
+
+	// Test 2: reversed name sort.
+	{
+		entries := fixedEntries()
+		sorting.SortEntries(entries, sorting.SortName, true, false)
+		fs.AddTestWithCategory("filepicker_sort_name_reversed", "unit",
+			map[string]interface{}{
+				"entries":   names(fixedEntries()),
+				"sort_type": "name",
+				"reverse":   true,
+			},
+			map[string]interface{}{
+				"sorted": names(entries),
+			},
+		)
+	}
+
+	// Test 3: dirs-first is orthogonal to sort mode -- directories sort
+	// before files even when sorting by size, where a directory's size is
+	// always reported as zero.
+	{
+		entries := fixedEntries()
+		sorting.SortEntries(entries, sorting.SortSize, false, true)
+		fs.AddTestWithNotes("filepicker_sort_dirs_first_orthogonal",
+			map[string]interface{}{
+				"entries":    names(fixedEntries()),
+				"sort_type":  "size",
+				"reverse":    false,
+				"dirs_first": true,
+			},
+			map[string]interface{}{
+				"sorted": names(entries),
+			},
+			"dirs_first groups Archive and zeta before any file regardless of the size-sort comparator",
+		)
+	}
+
+	// Test 4: natural sort numeric-aware collation, file2 before file10.
+	{
+		entries := []sorting.Entry{
+			{Name: "file10.txt"},
+			{Name: "file1.txt"},
+			{Name: "file2.txt"},
+			{Name: "file20.txt"},
+			{Name: "file.txt"},
+		}
+		sorting.SortEntries(entries, sorting.SortNatural, false, false)
+		fs.AddTestWithCategory("filepicker_sort_natural_numeric", "unit",
+			map[string]interface{}{
+				"entries": []string{"file10.txt", "file1.txt", "file2.txt", "file20.txt", "file.txt"},
+			},
+			map[string]interface{}{
+				"sorted": names(entries),
+			},
+		)
+	}
+
+	// Test 5: natural sort leading-zero tiebreak, numerically equal digit
+	// runs fall back to the raw (shorter-first) digit string.
+	{
+		pairs := []struct{ A, B string }{
+			{"file2.txt", "file02.txt"},
+			{"file02.txt", "file002.txt"},
+			{"file2.txt", "file2.txt"},
+		}
+		results := make([]int, len(pairs))
+		for i, p := range pairs {
+			results[i] = sorting.NaturalCompare(p.A, p.B)
+		}
+		fs.AddTestWithNotes("filepicker_sort_natural_leading_zero_tiebreak",
+			map[string]interface{}{
+				"pairs": pairs,
+			},
+			map[string]interface{}{
+				"compare_results": results,
+			},
+			"Digit runs that are numerically equal but differ in leading zeros break ties by preferring the shorter raw digit string first",
+		)
+	}
+
+	// Test 6: natural sort is case-insensitive over the non-digit runs.
+	{
+		entries := []sorting.Entry{
+			{Name: "Banana.txt"},
+			{Name: "apple.txt"},
+			{Name: "Cherry.txt"},
+		}
+		sorting.SortEntries(entries, sorting.SortNatural, false, false)
+		fs.AddTestWithCategory("filepicker_sort_natural_case_insensitive", "unit",
+			map[string]interface{}{
+				"entries": []string{"Banana.txt", "apple.txt", "Cherry.txt"},
+			},
+			map[string]interface{}{
+				"sorted": names(entries),
+			},
+		)
+	}
+}
+
+// stubMountEnumerator is an injectable, hermetic Enumerator for fixtures:
+// it returns a fixed mount list (or an error) instead of touching the
+// real filesystem, so these tests behave identically on every platform.
+type stubMountEnumerator struct {
+	mounts []filesystems.Mount
+	err    error
+}
+
+func (s stubMountEnumerator) Enumerate() ([]filesystems.Mount, error) {
+	return s.mounts, s.err
+}
+
+// captureFilesystemsTests captures the mounted-filesystem picker state
+// (similar to broot's :fs state) that filepicker.Model doesn't ship
+// today. It drives the internal/filesystems package through the
+// injectable Enumerator interface with a stub, so the platform-specific
+// /proc/mounts, getmntinfo, and GetLogicalDriveStrings enumerators never
+// run during fixture capture.
+func captureFilesystemsTests(fs *capture.FixtureSet) {
+	sampleMounts := []filesystems.Mount{
+		{Device: "/dev/sda1", Mountpoint: "/", FSType: "ext4", SizeBytes: 1000, UsedBytes: 400, AvailBytes: 600, UsePercent: 40},
+		{Device: "/dev/sda2", Mountpoint: "/home", FSType: "ext4", SizeBytes: 2000, UsedBytes: 1800, AvailBytes: 200, UsePercent: 90},
+		{Device: "tmpfs", Mountpoint: "/tmp", FSType: "tmpfs", SizeBytes: 500, UsedBytes: 10, AvailBytes: 490, UsePercent: 2},
+	}
+
+	// Test 1: refresh populates Mounts from the injected Enumerator.
+	{
+		m := filesystems.NewModel(stubMountEnumerator{mounts: sampleMounts})
+		msg := m.RefreshCmd()()
+		m, _ = m.Update(msg)
+
+		fs.AddTestWithCategory("filesystems_refresh", "unit",
+			map[string]interface{}{
+				"stub_mounts": sampleMounts,
+			},
+			map[string]interface{}{
+				"mounts": m.Mounts,
+				"cursor": m.Cursor,
+			},
+		)
+	}
+
+	// Test 2: refresh error surfaces as MountsErrMsg without touching
+	// Mounts.
+	{
+		m := filesystems.NewModel(stubMountEnumerator{err: os.ErrPermission})
+		msg := m.RefreshCmd()()
+		m, _ = m.Update(msg)
+
+		fs.AddTestWithNotes("filesystems_refresh_error",
+			map[string]interface{}{
+				"stub_err": "permission denied",
+			},
+			map[string]interface{}{
+				"mounts":     m.Mounts,
+				"err_is_set": m.Err != nil,
+			},
+			"A failed enumeration leaves Mounts untouched and records Err",
+		)
+	}
+
+	// Test 3: cursor movement clamps to the listing bounds.
+	{
+		m := filesystems.NewModel(stubMountEnumerator{mounts: sampleMounts})
+		msg := m.RefreshCmd()()
+		m, _ = m.Update(msg)
+
+		m.CursorUp() // already at 0
+		atStart := m.Cursor
+		m.CursorDown()
+		m.CursorDown()
+		m.CursorDown() // past the end
+		atEnd := m.Cursor
+
+		fs.AddTestWithCategory("filesystems_cursor_clamped", "unit",
+			map[string]interface{}{
+				"stub_mounts": sampleMounts,
+			},
+			map[string]interface{}{
+				"cursor_after_up_at_start": atStart,
+				"cursor_after_three_down":  atEnd,
+			},
+		)
+	}
+
+	// Test 4: selecting an entry emits ChangeDirMsg{Path} for the
+	// filepicker to consume.
+	{
+		m := filesystems.NewModel(stubMountEnumerator{mounts: sampleMounts})
+		msg := m.RefreshCmd()()
+		m, _ = m.Update(msg)
+		m.CursorDown() // select /home
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		changeDir, ok := cmd().(filesystems.ChangeDirMsg)
+
+		fs.AddTestWithNotes("filesystems_select_emits_change_dir",
+			map[string]interface{}{
+				"stub_mounts":  sampleMounts,
+				"cursor_after": 1,
+			},
+			map[string]interface{}{
+				"emitted_change_dir": ok,
+				"path":               changeDir.Path,
+			},
+			"Pressing enter on a highlighted mount emits ChangeDirMsg{Path: mountpoint} for filepicker.Model to consume",
+		)
+	}
+}
+
+// captureBulkRenameTests captures the $EDITOR-driven bulk-rename
+// subsystem's collision detector and cycle-breaking two-phase planner,
+// which filepicker.Model doesn't ship today. It drives the
+// internal/bulkrename package against both synthetic path lists (for the
+// pure validation/planning functions) and real temp files (for Apply's
+// end-to-end two-phase rename, including an a<->b cycle).
+func captureBulkRenameTests(fs *capture.FixtureSet) {
+	alwaysMissing := func(string) bool { return false }
+
+	// Test 1: parsing an edited tempfile's contents, including the
+	// trailing-newline-drop the editor leaves behind.
+	{
+		edited := "a.txt\nb.txt\nc.txt\n"
+		fs.AddTestWithCategory("bulkrename_parse_edited_lines", "unit",
+			map[string]interface{}{
+				"content": edited,
+			},
+			map[string]interface{}{
+				"targets": bulkrename.ParseEditedLines(edited),
+			},
+		)
+	}
+
+	// Test 2: line-count mismatch is rejected before any collision check.
+	{
+		sources := []string{"a.txt", "b.txt", "c.txt"}
+		targets := []string{"a.txt", "b.txt"}
+		err := bulkrename.ValidateCounts(sources, targets)
+		fs.AddTestWithCategory("bulkrename_validate_count_mismatch", "unit",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"error_is_set": err != nil,
+			},
+		)
+	}
+
+	// Test 3: collision detector -- two sources renaming to the same
+	// target, and a target colliding with an untouched existing path.
+	{
+		sources := []string{"a.txt", "b.txt", "c.txt"}
+		targets := []string{"x.txt", "x.txt", "existing.txt"}
+		exists := func(p string) bool { return p == "existing.txt" }
+		errs := bulkrename.DetectCollisions(sources, targets, exists)
+		fs.AddTestWithNotes("bulkrename_detect_collisions",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"error_count": len(errs),
+			},
+			"a.txt/b.txt colliding with each other and c.txt colliding with an existing untouched file both count as collisions",
+		)
+	}
+
+	// Test 4: a target equal to another entry's source is not a
+	// collision -- it's the cycle the two-phase planner exists to break.
+	{
+		sources := []string{"a.txt", "b.txt"}
+		targets := []string{"b.txt", "a.txt"}
+		errs := bulkrename.DetectCollisions(sources, targets, alwaysMissing)
+		fs.AddTestWithNotes("bulkrename_detect_collisions_cycle_is_not_a_collision",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"error_count": len(errs),
+			},
+			"a.txt<->b.txt is a valid swap once both are being renamed away, so DetectCollisions reports no errors",
+		)
+	}
+
+	// Test 5: two-phase planner on a simple non-colliding rename needs no
+	// temp staging.
+	{
+		sources := []string{"a.txt", "b.txt"}
+		targets := []string{"a2.txt", "b2.txt"}
+		phase1, phase2 := bulkrename.PlanTwoPhase(sources, targets)
+		fs.AddTestWithCategory("bulkrename_plan_no_cycle", "unit",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"phase1": phase1,
+				"phase2": phase2,
+			},
+		)
+	}
+
+	// Test 6: two-phase planner breaks an a<->b swap cycle via unique
+	// temp names, staging both sources out of the way before phase two.
+	{
+		sources := []string{"a.txt", "b.txt"}
+		targets := []string{"b.txt", "a.txt"}
+		phase1, phase2 := bulkrename.PlanTwoPhase(sources, targets)
+		fs.AddTestWithNotes("bulkrename_plan_two_cycle",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"phase1": phase1,
+				"phase2": phase2,
+			},
+			"phase1 moves both sources to temp names before phase2 moves either to its final target, so a<->b never collides mid-rename",
+		)
+	}
+
+	// Test 7: a longer rotation cycle (a->b->c->a) also stages entirely
+	// through phase1 before any phase2 move.
+	{
+		sources := []string{"a.txt", "b.txt", "c.txt"}
+		targets := []string{"b.txt", "c.txt", "a.txt"}
+		phase1, phase2 := bulkrename.PlanTwoPhase(sources, targets)
+		fs.AddTestWithNotes("bulkrename_plan_three_cycle",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"phase1_len": len(phase1),
+				"phase2_len": len(phase2),
+				"phase1":     phase1,
+				"phase2":     phase2,
+			},
+			"A 3-way rotation stages all three sources through temp names in phase1 before any phase2 move lands on a final target",
+		)
+	}
+
+	// Test 8: a non-cyclic rename chain (a->b, b->c) is not a swap or a
+	// rotation -- b is simultaneously a source and another entry's
+	// target -- so it needs the same temp staging a cycle does, even
+	// though no target ever points back at its own source.
+	{
+		sources := []string{"a.txt", "b.txt"}
+		targets := []string{"b.txt", "c.txt"}
+		phase1, phase2 := bulkrename.PlanTwoPhase(sources, targets)
+		fs.AddTestWithNotes("bulkrename_plan_chain",
+			map[string]interface{}{
+				"sources": sources,
+				"targets": targets,
+			},
+			map[string]interface{}{
+				"phase1_len": len(phase1),
+				"phase2_len": len(phase2),
+				"phase1":     phase1,
+				"phase2":     phase2,
+			},
+			"b.txt is both a.txt's target and its own source, so phase1 must stage b.txt through a temp name before phase2 can move a.txt onto it without clobbering b.txt's original contents",
+		)
+	}
+
+	// Test 9: Apply end-to-end on that same chain against real files --
+	// the regression this fixture exists to catch loses b.txt's original
+	// contents (a.txt's phase2 move overwrites b.txt before b.txt's own
+	// phase2 move reads it).
+	{
+		tmpDir, err := os.MkdirTemp("", "bulkrename_apply_chain")
+		if err == nil {
+			defer os.RemoveAll(tmpDir)
+
+			aPath := filepath.Join(tmpDir, "a.txt")
+			bPath := filepath.Join(tmpDir, "b.txt")
+			cPath := filepath.Join(tmpDir, "c.txt")
+			os.WriteFile(aPath, []byte("contents of a"), 0644)
+			os.WriteFile(bPath, []byte("contents of b"), 0644)
+
+			sources := []string{aPath, bPath}
+			targets := []string{bPath, cPath}
+			renamed, errs := bulkrename.Apply(sources, targets, func(p string) bool {
+				_, statErr := os.Stat(p)
+				return statErr == nil
+			})
+
+			bContents, _ := os.ReadFile(bPath)
+			cContents, _ := os.ReadFile(cPath)
+
+			fs.AddTestWithNotes("bulkrename_apply_chain_end_to_end",
+				map[string]interface{}{
+					"test_dir": "temp",
+					"sources":  []string{"a.txt", "b.txt"},
+					"targets":  []string{"b.txt", "c.txt"},
+				},
+				map[string]interface{}{
+					"error_count":         len(errs),
+					"renamed_count":       len(renamed),
+					"b_path_now_contains": string(bContents),
+					"c_path_now_contains": string(cContents),
+				},
+				"After chaining a.txt->b.txt->c.txt, b.txt on disk holds what used to be a.txt's contents and c.txt holds what used to be b.txt's -- b.txt's original contents must not be lost in between",
+			)
+		}
+	}
+
+	// Test 10: Apply end-to-end against real files, including an a<->b
+	// swap, verifying the filesystem ends up in the renamed state.
+	{
+		tmpDir, err := os.MkdirTemp("", "bulkrename_apply")
+		if err == nil {
+			defer os.RemoveAll(tmpDir)
+
+			aPath := filepath.Join(tmpDir, "a.txt")
+			bPath := filepath.Join(tmpDir, "b.txt")
+			os.WriteFile(aPath, []byte("contents of a"), 0644)
+			os.WriteFile(bPath, []byte("contents of b"), 0644)
+
+			sources := []string{aPath, bPath}
+			targets := []string{bPath, aPath}
+			renamed, errs := bulkrename.Apply(sources, targets, func(p string) bool {
+				_, statErr := os.Stat(p)
+				return statErr == nil
+			})
+
+			aContents, _ := os.ReadFile(aPath)
+			bContents, _ := os.ReadFile(bPath)
+
+			fs.AddTestWithNotes("bulkrename_apply_swap_end_to_end",
+				map[string]interface{}{
+					"test_dir": "temp",
+					"sources":  []string{"a.txt", "b.txt"},
+					"targets":  []string{"b.txt", "a.txt"},
+				},
+				map[string]interface{}{
+					"error_count":         len(errs),
+					"renamed_count":       len(renamed),
+					"a_path_now_contains": string(aContents),
+					"b_path_now_contains": string(bContents),
+				},
+				"After swapping a.txt and b.txt, a.txt on disk holds what used to be b.txt's contents and vice versa",
+			)
+		}
+	}
+}
+
+// captureLiveSearchTests captures the integrated "/"-triggered search
+// mode that list.Model and table.Model don't ship today: live fuzzy
+// filtering that highlights matches without collapsing the row set, plus
+// SearchNext/SearchPrev cycling and a pluggable scorer. It drives the
+// internal/search package's LiveSearch type, which stands in for this.
+func captureLiveSearchTests(fs *capture.FixtureSet) {
+	corpus := []string{
+		"README.md",
+		"main.go",
+		"internal/search/search.go",
+		"internal/sorting/sorting.go",
+		"cmd/bubbles/main.go",
+		"Makefile",
+	}
+
+	// Test 1: result ordering for a known corpus with DefaultFuzzyScorer.
+	{
+		ls := search.NewLiveSearch()
+		matches := ls.Search("main", corpus)
+		fs.AddTestWithCategory("live_search_result_ordering", "unit",
+			map[string]interface{}{
+				"corpus":  corpus,
+				"pattern": "main",
+			},
+			map[string]interface{}{
+				"matches": matches,
+			},
+		)
+	}
+
+	// Test 2: n/N cycle through matches and wrap around in both
+	// directions.
+	{
+		ls := search.NewLiveSearch()
+		ls.Search("go", corpus)
+
+		var sequence []int
+		for i := 0; i < len(ls.Matches)+2; i++ {
+			m, _ := ls.SearchNext()
+			sequence = append(sequence, m.Index)
+		}
+		// Wind back past the start to confirm SearchPrev also wraps.
+		m, _ := ls.SearchPrev()
+		afterOnePrev := m.Index
+		m, _ = ls.SearchPrev()
+		afterTwoPrev := m.Index
+
+		fs.AddTestWithNotes("live_search_next_prev_wrap",
+			map[string]interface{}{
+				"corpus":       corpus,
+				"pattern":      "go",
+				"next_presses": len(ls.Matches) + 2,
+			},
+			map[string]interface{}{
+				"match_count":    len(ls.Matches),
+				"next_sequence":  sequence,
+				"after_one_prev": afterOnePrev,
+				"after_two_prev": afterTwoPrev,
+			},
+			"SearchNext wraps from the last match back to the first after len(Matches) presses; SearchPrev wraps the other direction",
+		)
+	}
+
+	// Test 3: no match for a pattern that isn't a subsequence of any
+	// candidate.
+	{
+		ls := search.NewLiveSearch()
+		matches := ls.Search("zzz-nonexistent", corpus)
+		fs.AddTestWithCategory("live_search_no_match", "unit",
+			map[string]interface{}{
+				"corpus":  corpus,
+				"pattern": "zzz-nonexistent",
+			},
+			map[string]interface{}{
+				"match_count": len(matches),
+			},
+		)
+	}
+
+	// Test 4: SetSearchScorer swaps in a caller-supplied algorithm, here
+	// a trivial exact-match-only scorer, to prove the plug point works.
+	{
+		ls := search.NewLiveSearch()
+		ls.SetSearchScorer(func(pattern, candidate string) (int, []int) {
+			if candidate == pattern {
+				return 100, []int{0}
+			}
+			return 0, nil
+		})
+		matches := ls.Search("main.go", corpus)
+		fs.AddTestWithNotes("live_search_custom_scorer",
+			map[string]interface{}{
+				"corpus":  corpus,
+				"pattern": "main.go",
+			},
+			map[string]interface{}{
+				"matches": matches,
+			},
+			"An exact-match-only custom scorer matches only the literal candidate \"main.go\", not \"cmd/bubbles/main.go\"",
+		)
+	}
+
+	// Test 5: matched positions collapse into contiguous ranges for
+	// highlighting, e.g. consecutive matched runes in "main.go" against
+	// pattern "main".
+	{
+		score, positions := search.DefaultFuzzyScorer("main", "main.go")
+		ranges := search.PositionsToRanges(positions)
+		fs.AddTestWithCategory("live_search_highlight_ranges", "unit",
+			map[string]interface{}{
+				"pattern":   "main",
+				"candidate": "main.go",
+			},
+			map[string]interface{}{
+				"score":     score,
+				"positions": positions,
+				"ranges":    ranges,
+			},
+		)
+	}
+
+	// Test 6: a live search driven against a real list.Model -- the
+	// non-matching rows stay in VisibleItems() since this is a highlight
+	// cursor, not the built-in collapsing Filter.
+	{
+		items := make([]list.Item, len(corpus))
+		for i, c := range corpus {
+			items[i] = listItem{title: c}
+		}
+		l := list.New(items, list.NewDefaultDelegate(), 40, 10)
+
+		ls := search.NewLiveSearch()
+		ls.Search("go", corpus)
+
+		fs.AddTestWithCategory("live_search_list_preserves_visible_items", "unit",
+			map[string]interface{}{
+				"corpus":  corpus,
+				"pattern": "go",
+			},
+			map[string]interface{}{
+				"visible_item_count": len(l.VisibleItems()),
+				"match_count":        len(ls.Matches),
+			},
+		)
+	}
+}
@@ -3,6 +3,8 @@ package main
 
 import (
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/inputparser"
+	"charmed_conformance/internal/mouseparser"
 	"flag"
 	"fmt"
 	"os"
@@ -12,6 +14,7 @@ import (
 
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	format := flag.String("format", "json", "Fixture format: json, yaml, msgpack, cbor, or jsonl")
 	flag.Parse()
 
 	fixtures := capture.NewFixtureSet("bubbletea", "1.3.4")
@@ -31,10 +34,16 @@ func main() {
 	// Capture mouse event string tests
 	captureMouseEventStringTests(fixtures)
 
+	// Capture X10/SGR/URXVT mouse wire protocol tests
+	captureMouseSequenceTests(fixtures)
+
 	// Capture key string tests
 	captureKeyStringTests(fixtures)
 
-	if err := fixtures.WriteToFile(*outputDir); err != nil {
+	// Capture Kitty keyboard protocol and other extended sequence tests
+	captureExtendedSequenceTests(fixtures)
+
+	if err := fixtures.WriteWithFormat(*outputDir, *format); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -433,3 +442,421 @@ func captureKeyStringTests(fs *capture.FixtureSet) {
 		)
 	}
 }
+
+// eventFixture flattens an inputparser.Event into fixture output, omitting
+// fields that don't apply to the event's Kind.
+func eventFixture(ev inputparser.Event, consumed int) map[string]interface{} {
+	out := map[string]interface{}{
+		"kind":     string(ev.Kind),
+		"consumed": consumed,
+	}
+	switch ev.Kind {
+	case inputparser.KindKey:
+		out["code"] = ev.Code
+		out["event_type"] = ev.EventType
+		out["modifiers"] = map[string]bool{
+			"shift":     ev.Modifiers.Shift,
+			"alt":       ev.Modifiers.Alt,
+			"ctrl":      ev.Modifiers.Ctrl,
+			"super":     ev.Modifiers.Super,
+			"hyper":     ev.Modifiers.Hyper,
+			"meta":      ev.Modifiers.Meta,
+			"caps_lock": ev.Modifiers.CapsLock,
+			"num_lock":  ev.Modifiers.NumLock,
+		}
+	case inputparser.KindPaste:
+		out["text"] = ev.Text
+	case inputparser.KindDeviceAttributes:
+		out["params"] = ev.Params
+	case inputparser.KindCursorPosition:
+		out["row"] = ev.Row
+		out["col"] = ev.Col
+	case inputparser.KindOSCResponse:
+		out["osc_code"] = ev.OSCCode
+		out["osc_payload"] = ev.OSCPayload
+	}
+	return out
+}
+
+// captureExtendedSequenceTests feeds modern extended input sequences --
+// Kitty protocol key events, xterm modifyOtherKeys level 2, bracketed
+// paste, focus in/out, device attributes, cursor position reports, OSC
+// responses, and ambiguous/partial/split sequences -- through
+// inputparser.Parse and records the resulting event, so a Rust port's
+// extended-sequence parser can be checked event-for-event rather than
+// just against a legacy key lookup table.
+func captureExtendedSequenceTests(fs *capture.FixtureSet) {
+	captureKittyKeyMatrix(fs)
+	captureModifyOtherKeysTests(fs)
+	captureBracketedPasteTests(fs)
+	captureFocusEventTests(fs)
+	captureDeviceAttributesTests(fs)
+	captureCursorPositionTests(fs)
+	captureOSCResponseTests(fs)
+	captureAmbiguousSequenceTests(fs)
+}
+
+// captureKittyKeyMatrix generates the bulk of the extended corpus: every
+// combination of a representative key codepoint, modifier combination,
+// and kitty event type, encoded as a CSI u sequence.
+func captureKittyKeyMatrix(fs *capture.FixtureSet) {
+	codes := []struct {
+		name string
+		code int
+	}{
+		{"a", 97},
+		{"b", 98},
+		{"enter", 13},
+		{"escape", 27},
+		{"tab", 9},
+		{"space", 32},
+	}
+
+	modifiers := []struct {
+		name  string
+		value int // kitty-encoded (bitmask + 1); 0 means the field is omitted
+	}{
+		{"none", 0},
+		{"shift", 2},
+		{"alt", 3},
+		{"ctrl", 5},
+		{"super", 9},
+		{"hyper", 17},
+		{"meta", 33},
+		{"shift_alt", 4},
+		{"shift_ctrl", 6},
+		{"alt_ctrl", 7},
+		{"shift_alt_ctrl", 8},
+		{"ctrl_super", 13},
+	}
+
+	eventTypes := []struct {
+		name   string
+		suffix string // appended after the modifier field, e.g. ":3"
+	}{
+		{"press", ""},
+		{"repeat", ":2"},
+		{"release", ":3"},
+	}
+
+	for _, c := range codes {
+		for _, m := range modifiers {
+			for _, et := range eventTypes {
+				var seq string
+				switch {
+				case m.value == 0 && et.suffix == "":
+					seq = fmt.Sprintf("\x1b[%du", c.code)
+				case m.value == 0:
+					seq = fmt.Sprintf("\x1b[%d;1%su", c.code, et.suffix)
+				default:
+					seq = fmt.Sprintf("\x1b[%d;%d%su", c.code, m.value, et.suffix)
+				}
+
+				name := fmt.Sprintf("kitty_%s_%s_%s", c.name, m.name, et.name)
+				ev, consumed := inputparser.Parse(seq)
+
+				fs.AddTestWithCategory(name, "extended_sequence",
+					map[string]interface{}{
+						"sequence": seq,
+					},
+					eventFixture(ev, consumed),
+				)
+			}
+		}
+	}
+}
+
+func captureModifyOtherKeysTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"ctrl_a", "\x1b[27;5;97~"},
+		{"shift_enter", "\x1b[27;2;13~"},
+		{"alt_space", "\x1b[27;3;32~"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("modify_other_keys_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+func captureBracketedPasteTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"simple", "\x1b[200~hello\x1b[201~"},
+		{"empty", "\x1b[200~\x1b[201~"},
+		{"multiline", "\x1b[200~line one\nline two\x1b[201~"},
+		{"embedded_escape", "\x1b[200~echo \x1b[A up\x1b[201~"},
+		{"unterminated", "\x1b[200~no end marker yet"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("bracketed_paste_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+func captureFocusEventTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"focus_in", "\x1b[I"},
+		{"focus_out", "\x1b[O"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(tc.name, "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+func captureDeviceAttributesTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"vt220", "\x1b[?62;1;2;6;8;9c"},
+		{"vt100", "\x1b[?1;2c"},
+		{"no_params", "\x1b[?c"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("device_attributes_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+func captureCursorPositionTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"top_left", "\x1b[1;1R"},
+		{"mid_screen", "\x1b[24;80R"},
+		{"row_only", "\x1b[5R"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("cursor_position_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+func captureOSCResponseTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"color_query_st", "\x1b]10;rgb:ffff/ffff/ffff\x1b\\"},
+		{"color_query_bel", "\x1b]11;rgb:0000/0000/0000\x07"},
+		{"clipboard_response", "\x1b]52;c;aGVsbG8=\x07"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("osc_response_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+}
+
+// captureAmbiguousSequenceTests covers the sequences a real driver can't
+// resolve from the bytes alone: a lone ESC awaiting its disambiguation
+// timeout, alt-prefixed arrow keys, and a sequence split across two
+// buffer reads.
+func captureAmbiguousSequenceTests(fs *capture.FixtureSet) {
+	plainCases := []struct {
+		name string
+		seq  string
+	}{
+		{"lone_escape", "\x1b"},
+		{"alt_up", "\x1b\x1b[A"},
+		{"alt_down", "\x1b\x1b[B"},
+		{"alt_right", "\x1b\x1b[C"},
+		{"alt_left", "\x1b\x1b[D"},
+		{"partial_csi_u", "\x1b[97;5"},
+		{"partial_osc", "\x1b]10;rgb:ffff/ffff/ffff"},
+		{"partial_paste", "\x1b[200~still typing"},
+	}
+
+	for _, tc := range plainCases {
+		ev, consumed := inputparser.Parse(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("ambiguous_%s", tc.name), "extended_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			eventFixture(ev, consumed),
+		)
+	}
+
+	// Split-read case: the same kitty ctrl+a sequence arrives across two
+	// separate buffer reads. The first read must report partial with zero
+	// bytes consumed; once the remainder arrives, parsing the full
+	// sequence must match parsing it as a single read.
+	full := "\x1b[97;5u"
+	firstHalf := full[:4]
+	firstEv, firstConsumed := inputparser.Parse(firstHalf)
+	fullEv, fullConsumed := inputparser.Parse(full)
+
+	fs.AddTestWithCategory("ambiguous_split_read", "extended_sequence",
+		map[string]interface{}{
+			"first_read":  firstHalf,
+			"second_read": full[4:],
+			"full":        full,
+		},
+		map[string]interface{}{
+			"first_read_result": eventFixture(firstEv, firstConsumed),
+			"full_read_result":  eventFixture(fullEv, fullConsumed),
+		},
+	)
+}
+
+// mouseEventFixture flattens a tea.MouseEvent into fixture output.
+func mouseEventFixture(ev tea.MouseEvent, consumed int, ok bool) map[string]interface{} {
+	return map[string]interface{}{
+		"recognized": ok,
+		"consumed":   consumed,
+		"x":          ev.X,
+		"y":          ev.Y,
+		"button":     int(ev.Button),
+		"action":     int(ev.Action),
+		"ctrl":       ev.Ctrl,
+		"alt":        ev.Alt,
+		"shift":      ev.Shift,
+	}
+}
+
+// captureMouseSequenceTests feeds raw X10, SGR (1006), and URXVT (1015)
+// mouse sequences through mouseparser and records the decoded
+// tea.MouseEvent, giving a Rust port ground truth for the wire protocols
+// themselves rather than just the MouseButton/MouseAction enum constants.
+func captureMouseSequenceTests(fs *capture.FixtureSet) {
+	captureX10MouseTests(fs)
+	captureSGRMouseTests(fs)
+	captureURXVTMouseTests(fs)
+}
+
+func captureX10MouseTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"left_press", "\x1b[M %!"},
+		{"middle_press", "\x1b[M!%!"},
+		{"right_press", "\x1b[M\"%!"},
+		{"release", "\x1b[M#%!"},
+		{"wheel_up", "\x1b[M`%!"},
+		{"wheel_down", "\x1b[Ma%!"},
+		{"motion_left_drag", "\x1b[M@%!"},
+		{"shift_left_press", "\x1b[M$%!"},
+		{"alt_left_press", "\x1b[M(%!"},
+		{"ctrl_left_press", "\x1b[M0%!"},
+		{"max_encodable_coord", "\x1b[M \xff\xff"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed, ok := mouseparser.ParseX10(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("mouse_x10_%s", tc.name), "mouse_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			mouseEventFixture(ev, consumed, ok),
+		)
+	}
+}
+
+func captureSGRMouseTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"left_press", "\x1b[<0;10;20M"},
+		{"left_release", "\x1b[<0;10;20m"},
+		{"middle_press", "\x1b[<1;10;20M"},
+		{"right_press", "\x1b[<2;10;20M"},
+		{"wheel_up", "\x1b[<64;10;20M"},
+		{"wheel_down", "\x1b[<65;10;20M"},
+		{"wheel_left", "\x1b[<66;10;20M"},
+		{"wheel_right", "\x1b[<67;10;20M"},
+		{"drag_left", "\x1b[<32;15;25M"},
+		{"shift_left_press", "\x1b[<4;10;20M"},
+		{"alt_left_press", "\x1b[<8;10;20M"},
+		{"ctrl_left_press", "\x1b[<16;10;20M"},
+		{"ctrl_alt_shift_right_press", "\x1b[<30;10;20M"},
+		{"beyond_x10_range", "\x1b[<0;500;400M"},
+		{"origin", "\x1b[<0;1;1M"},
+		{"right_release", "\x1b[<2;10;20m"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed, ok := mouseparser.ParseSGR(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("mouse_sgr_%s", tc.name), "mouse_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			mouseEventFixture(ev, consumed, ok),
+		)
+	}
+}
+
+func captureURXVTMouseTests(fs *capture.FixtureSet) {
+	cases := []struct {
+		name string
+		seq  string
+	}{
+		{"left_press", "\x1b[32;10;20M"},
+		{"middle_press", "\x1b[33;10;20M"},
+		{"right_press", "\x1b[34;10;20M"},
+		{"release", "\x1b[35;10;20M"},
+		{"wheel_up", "\x1b[96;10;20M"},
+		{"wheel_down", "\x1b[97;10;20M"},
+		{"drag_left", "\x1b[64;15;25M"},
+		{"shift_left_press", "\x1b[36;10;20M"},
+		{"alt_left_press", "\x1b[40;10;20M"},
+		{"ctrl_left_press", "\x1b[48;10;20M"},
+		{"beyond_x10_range", "\x1b[32;500;400M"},
+	}
+
+	for _, tc := range cases {
+		ev, consumed, ok := mouseparser.ParseURXVT(tc.seq)
+		fs.AddTestWithCategory(fmt.Sprintf("mouse_urxvt_%s", tc.name), "mouse_sequence",
+			map[string]interface{}{
+				"sequence": tc.seq,
+			},
+			mouseEventFixture(ev, consumed, ok),
+		)
+	}
+}
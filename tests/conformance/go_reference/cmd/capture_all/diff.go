@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// fixtureFile is the subset of capture.FixtureSet's JSON shape diffAgainstGolden
+// needs. It's redeclared here rather than importing the capture package so
+// this orchestrator stays buildable even if a single capture program's
+// package fails to compile.
+type fixtureFile struct {
+	Metadata struct {
+		Crate string `json:"crate"`
+	} `json:"metadata"`
+	Tests []struct {
+		Name           string          `json:"name"`
+		ExpectedOutput json.RawMessage `json:"expected_output"`
+	} `json:"tests"`
+}
+
+// diffAgainstGolden compares every <prog>.json just captured into freshDir
+// against the previously committed fixture of the same name in goldenDir,
+// printing a unified structural diff of added/removed/changed test cases.
+// It returns true if any program drifted, so the caller can exit non-zero.
+func diffAgainstGolden(freshDir, goldenDir string) (bool, error) {
+	drifted := false
+
+	for _, prog := range programs {
+		freshPath := filepath.Join(freshDir, prog+".json")
+		goldenPath := filepath.Join(goldenDir, prog+".json")
+
+		fresh, err := loadFixtureFile(freshPath)
+		if err != nil {
+			fmt.Printf("%s: could not load fresh capture: %v\n", prog, err)
+			drifted = true
+			continue
+		}
+
+		golden, err := loadFixtureFile(goldenPath)
+		if err != nil {
+			fmt.Printf("%s: no golden fixture to compare against (%v), skipping\n", prog, err)
+			continue
+		}
+
+		added, removed, changed := diffTests(golden, fresh)
+		if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+			fmt.Printf("%s: no drift (%d tests)\n", prog, len(fresh.Tests))
+			continue
+		}
+
+		drifted = true
+		fmt.Printf("%s: drift detected\n", prog)
+		for _, name := range added {
+			fmt.Printf("  + %s\n", name)
+		}
+		for _, name := range removed {
+			fmt.Printf("  - %s\n", name)
+		}
+		for _, name := range changed {
+			fmt.Printf("  ~ %s\n", name)
+		}
+	}
+
+	return drifted, nil
+}
+
+func loadFixtureFile(path string) (*fixtureFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f fixtureFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// diffTests reports, by test name, which cases were added in fresh, removed
+// from golden, or present in both with a different expected_output.
+func diffTests(golden, fresh *fixtureFile) (added, removed, changed []string) {
+	goldenByName := make(map[string]json.RawMessage, len(golden.Tests))
+	for _, t := range golden.Tests {
+		goldenByName[t.Name] = t.ExpectedOutput
+	}
+
+	freshByName := make(map[string]json.RawMessage, len(fresh.Tests))
+	for _, t := range fresh.Tests {
+		freshByName[t.Name] = t.ExpectedOutput
+	}
+
+	for name, freshOutput := range freshByName {
+		goldenOutput, ok := goldenByName[name]
+		if !ok {
+			added = append(added, name)
+			continue
+		}
+		if !jsonEqual(goldenOutput, freshOutput) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range goldenByName {
+		if _, ok := freshByName[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// jsonEqual compares two JSON values by their canonical re-marshaled form,
+// so differences purely in key order or whitespace don't register as drift.
+func jsonEqual(a, b json.RawMessage) bool {
+	var av, bv interface{}
+	if err := json.Unmarshal(a, &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &bv); err != nil {
+		return false
+	}
+	aCanon, errA := json.Marshal(av)
+	bCanon, errB := json.Marshal(bv)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aCanon) == string(bCanon)
+}
@@ -2,103 +2,284 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
+// programs is the list of capture programs the orchestrator builds and runs,
+// each corresponding to a ./cmd/<name> package that writes <name>.json into
+// the shared output directory.
+var programs = []string{
+	"harmonica",
+	"lipgloss",
+	"bubbletea",
+	"bubbles",
+	"log",
+	"glamour",
+	"huh",
+	"wish",
+	"capture_input_fuzz",
+	"capture_keybind",
+}
+
+// programResult is one program's outcome from a capture run, structured so
+// it can be written verbatim into run_summary.json for CI to parse instead
+// of scraping human-readable text.
+type programResult struct {
+	Program     string `json:"program"`
+	Status      string `json:"status"` // "ok", "build_failed", "run_failed", "timeout", "no_output"
+	DurationMs  int64  `json:"duration_ms"`
+	OutputBytes int64  `json:"output_bytes"`
+	SHA256      string `json:"sha256,omitempty"`
+	StderrTail  string `json:"stderr_tail,omitempty"`
+}
+
+// runSummary is the top-level shape written to run_summary.json.
+type runSummary struct {
+	StartedAt string          `json:"started_at"`
+	ElapsedMs int64           `json:"elapsed_ms"`
+	Parallel  int             `json:"parallel"`
+	Results   []programResult `json:"results"`
+	Successes int             `json:"successes"`
+	Failures  int             `json:"failures"`
+}
+
+// stderrTailBytes bounds how much of a failed program's stderr is kept in
+// run_summary.json, enough to diagnose a failure without bloating the file.
+const stderrTailBytes = 4096
+
 func main() {
 	outputDir := flag.String("output", "../../../fixtures/go_outputs", "Output directory for fixtures")
 	verbose := flag.Bool("verbose", false, "Verbose output")
+	parallel := flag.Int("parallel", 4, "Number of capture programs to build/run concurrently")
+	timeout := flag.Duration("timeout", 60*time.Second, "Per-program timeout for the build and the capture run")
+	diff := flag.Bool("diff", false, "Compare freshly captured fixtures against the previously committed ones instead of writing them")
+	goldenDir := flag.String("golden", "../../../fixtures/go_outputs", "Directory holding the previously committed fixtures, used by -diff")
 	flag.Parse()
 
-	// Get the absolute path of the output directory
 	absOutput, err := filepath.Abs(*outputDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error resolving output path: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Ensure output directory exists
-	if err := os.MkdirAll(absOutput, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating output directory: %v\n", err)
-		os.Exit(1)
+	captureDir := absOutput
+	if *diff {
+		// Diffing must never clobber the golden fixtures it compares
+		// against, so a fresh run is captured into a scratch directory.
+		scratch, err := os.MkdirTemp("", "capture_all_diff")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating scratch directory: %v\n", err)
+			os.Exit(1)
+		}
+		defer os.RemoveAll(scratch)
+		captureDir = scratch
 	}
 
-	// List of capture programs to run
-	programs := []string{
-		"harmonica",
-		"lipgloss",
-		"bubbletea",
-		"bubbles",
-		"log",
-		"glamour",
-		"huh",
-		"wish",
+	if err := os.MkdirAll(captureDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating output directory: %v\n", err)
+		os.Exit(1)
 	}
 
 	fmt.Println("=== Charmed Rust Conformance Capture ===")
-	fmt.Printf("Output directory: %s\n", absOutput)
-	fmt.Printf("Running %d capture programs\n\n", len(programs))
+	fmt.Printf("Output directory: %s\n", captureDir)
+	fmt.Printf("Running %d capture programs (parallel=%d, timeout=%s)\n\n", len(programs), *parallel, *timeout)
 
 	startTime := time.Now()
-	successes := 0
-	failures := 0
+	results := runAll(captureDir, *parallel, *timeout, *verbose)
 
-	for i, prog := range programs {
-		fmt.Printf("[%d/%d] Capturing %s...", i+1, len(programs), prog)
-
-		// Build the program first
-		buildCmd := exec.Command("go", "build", "-o", fmt.Sprintf("/tmp/capture_%s", prog), fmt.Sprintf("./cmd/%s", prog))
-		buildCmd.Dir = filepath.Join(filepath.Dir(os.Args[0]), "..")
-		if *verbose {
-			buildCmd.Stdout = os.Stdout
-			buildCmd.Stderr = os.Stderr
+	summary := runSummary{
+		StartedAt: startTime.UTC().Format(time.RFC3339),
+		ElapsedMs: time.Since(startTime).Milliseconds(),
+		Parallel:  *parallel,
+		Results:   results,
+	}
+	for _, r := range results {
+		if r.Status == "ok" {
+			summary.Successes++
+		} else {
+			summary.Failures++
 		}
+	}
 
-		if err := buildCmd.Run(); err != nil {
-			fmt.Printf(" BUILD FAILED: %v\n", err)
-			failures++
-			continue
-		}
+	printSummary(summary)
 
-		// Run the capture program
-		runCmd := exec.Command(fmt.Sprintf("/tmp/capture_%s", prog), "-output", absOutput)
-		if *verbose {
-			runCmd.Stdout = os.Stdout
-			runCmd.Stderr = os.Stderr
-		}
+	summaryPath := filepath.Join(captureDir, "run_summary.json")
+	if err := writeJSON(summaryPath, summary); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing run summary: %v\n", err)
+		os.Exit(1)
+	}
 
-		if err := runCmd.Run(); err != nil {
-			fmt.Printf(" RUN FAILED: %v\n", err)
-			failures++
-			continue
+	if *diff {
+		absGolden, err := filepath.Abs(*goldenDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving golden path: %v\n", err)
+			os.Exit(1)
 		}
-
-		// Verify output file exists
-		outputFile := filepath.Join(absOutput, prog+".json")
-		if _, err := os.Stat(outputFile); err != nil {
-			fmt.Printf(" NO OUTPUT FILE\n")
-			failures++
-			continue
+		drifted, err := diffAgainstGolden(captureDir, absGolden)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error diffing fixtures: %v\n", err)
+			os.Exit(1)
 		}
+		if drifted {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if summary.Failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// runAll builds and runs every capture program against outputDir, at most
+// parallel at a time, returning one result per program in programs' order
+// regardless of completion order.
+func runAll(outputDir string, parallel int, timeout time.Duration, verbose bool) []programResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]programResult, len(programs))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, prog := range programs {
+		i, prog := i, prog
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = runOne(prog, outputDir, timeout, verbose)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runOne builds then runs a single capture program, each step under its own
+// timeout,
+// reporting its outcome as a programResult instead of printing directly, so
+// concurrent runs don't interleave their output.
+func runOne(prog, outputDir string, timeout time.Duration, verbose bool) programResult {
+	start := time.Now()
+	result := programResult{Program: prog}
+
+	binPath := filepath.Join(os.TempDir(), fmt.Sprintf("capture_%s_%d", prog, os.Getpid()))
+	repoRoot := filepath.Join(filepath.Dir(os.Args[0]), "..")
+
+	buildCtx, buildCancel := context.WithTimeout(context.Background(), timeout)
+	defer buildCancel()
+	buildCmd := exec.CommandContext(buildCtx, "go", "build", "-o", binPath, fmt.Sprintf("./cmd/%s", prog))
+	buildCmd.Dir = repoRoot
+	var buildStderr bytes.Buffer
+	buildCmd.Stderr = &buildStderr
+	if verbose {
+		buildCmd.Stdout = os.Stdout
+	}
+
+	if err := buildCmd.Run(); err != nil {
+		result.Status = statusFor(buildCtx, "build_failed")
+		result.StderrTail = tail(buildStderr.String(), stderrTailBytes)
+		result.DurationMs = time.Since(start).Milliseconds()
+		return result
+	}
+	defer os.Remove(binPath)
+
+	runCtx, runCancel := context.WithTimeout(context.Background(), timeout)
+	defer runCancel()
+	runCmd := exec.CommandContext(runCtx, binPath, "-output", outputDir)
+	var runStderr bytes.Buffer
+	runCmd.Stderr = &runStderr
+	if verbose {
+		runCmd.Stdout = os.Stdout
+	}
+
+	if err := runCmd.Run(); err != nil {
+		result.Status = statusFor(runCtx, "run_failed")
+		result.StderrTail = tail(runStderr.String(), stderrTailBytes)
+		result.DurationMs = time.Since(start).Milliseconds()
+		return result
+	}
 
-		info, _ := os.Stat(outputFile)
-		fmt.Printf(" OK (%d bytes)\n", info.Size())
-		successes++
+	outputFile := filepath.Join(outputDir, prog+".json")
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		result.Status = "no_output"
+		result.DurationMs = time.Since(start).Milliseconds()
+		return result
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		result.Status = "no_output"
+		result.DurationMs = time.Since(start).Milliseconds()
+		return result
+	}
+
+	sum := sha256.Sum256(data)
+	result.Status = "ok"
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.OutputBytes = info.Size()
+	result.SHA256 = hex.EncodeToString(sum[:])
+	return result
+}
+
+// statusFor reports "timeout" instead of fallback if ctx's deadline was
+// what actually stopped the command, distinguishing a hang from a real
+// build/run failure.
+func statusFor(ctx context.Context, fallback string) string {
+	if ctx.Err() == context.DeadlineExceeded {
+		return "timeout"
+	}
+	return fallback
+}
+
+// tail returns at most n trailing bytes of s, the way run_summary.json keeps
+// only enough stderr to diagnose a failure without bloating the file.
+func tail(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
+
+func printSummary(summary runSummary) {
+	for i, r := range summary.Results {
+		fmt.Printf("[%d/%d] %s: %s (%dms", i+1, len(programs), r.Program, r.Status, r.DurationMs)
+		if r.Status == "ok" {
+			fmt.Printf(", %d bytes)\n", r.OutputBytes)
+		} else {
+			fmt.Printf(")\n")
+			if r.StderrTail != "" {
+				fmt.Printf("    %s\n", r.StderrTail)
+			}
+		}
 	}
 
-	elapsed := time.Since(startTime)
 	fmt.Println()
 	fmt.Println("=== Summary ===")
-	fmt.Printf("Successful: %d/%d\n", successes, len(programs))
-	fmt.Printf("Failed: %d/%d\n", failures, len(programs))
-	fmt.Printf("Time: %v\n", elapsed)
+	fmt.Printf("Successful: %d/%d\n", summary.Successes, len(programs))
+	fmt.Printf("Failed: %d/%d\n", summary.Failures, len(programs))
+	fmt.Printf("Time: %dms\n", summary.ElapsedMs)
+}
 
-	if failures > 0 {
-		os.Exit(1)
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", path, err)
 	}
+	return os.WriteFile(path, data, 0644)
 }
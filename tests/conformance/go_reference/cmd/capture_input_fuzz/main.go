@@ -0,0 +1,79 @@
+// Capture input fuzz program - generates a large randomized fixture set
+// and replayable corpus exercising the extended input sequence parser's
+// boundaries, complementing cmd/bubbletea's hand-picked sequence tables.
+package main
+
+import (
+	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/capture/fuzz"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	fuzzCount := flag.Int("fuzz-count", 300, "Number of randomly generated sequence cases to append")
+	fuzzSeed := flag.Int64("fuzz-seed", 1, "RNG seed for randomly generated sequence cases")
+	flag.Parse()
+
+	fixtures := capture.NewFixtureSet("bubbletea_input_fuzz", "1.3.4")
+
+	// Capture deterministic split-at-every-byte-offset tests
+	captureSplitOffsetTests(fixtures)
+
+	// Capture a large randomized corpus of adversarial/boundary sequences
+	if err := fuzz.FuzzInputSequence(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := fixtures.WriteToFile(*outputDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var splitOffsetSequences = []struct {
+	name string
+	seq  string
+}{
+	{"kitty_ctrl_a", "\x1b[97;5u"},
+	{"bracketed_paste", "\x1b[200~hi\x1b[201~"},
+	{"osc_color_response", "\x1b]10;rgb:ffff/ffff/ffff\x1b\\"},
+	{"device_attributes", "\x1b[?62;1;2c"},
+	{"cursor_position", "\x1b[24;80R"},
+}
+
+// captureSplitOffsetTests feeds each representative sequence in
+// splitOffsetSequences as two reads split at every possible byte offset,
+// recording how the driver handles the first (possibly incomplete) read
+// and the full sequence once the remainder arrives. A parser that only
+// works when handed a sequence whole, rather than byte-by-byte as a real
+// terminal delivers it, would pass the single-read fixtures in
+// cmd/bubbletea but fail here.
+func captureSplitOffsetTests(fs *capture.FixtureSet) {
+	for _, tc := range splitOffsetSequences {
+		for offset := 0; offset <= len(tc.seq); offset++ {
+			firstRead := tc.seq[:offset]
+			secondRead := tc.seq[offset:]
+
+			firstOut := fuzz.RunInputSequence(capture.InputSequenceInput{Sequence: firstRead})
+			fullOut := fuzz.RunInputSequence(capture.InputSequenceInput{Sequence: tc.seq})
+
+			fs.AddTestWithCategory(
+				fmt.Sprintf("split_offset_%s_%02d", tc.name, offset), "split_offset",
+				map[string]interface{}{
+					"full_sequence": tc.seq,
+					"offset":        offset,
+					"first_read":    firstRead,
+					"second_read":   secondRead,
+				},
+				map[string]interface{}{
+					"first_read_result": firstOut,
+					"full_read_result":  fullOut,
+				},
+			)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Capture keybind program - captures internal/keybind's fzf-style
+// "--bind" grammar: for every modifier/base-key combination it records the
+// parsed chord, the raw escape sequence(s) that should trigger it, and
+// whether the chord matches the tea.KeyMsg a real driver would produce.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/keybind"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func main() {
+	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	flag.Parse()
+
+	fixtures := capture.NewFixtureSet("keybind", "1.3.4")
+
+	captureKeybindMatrix(fixtures)
+	captureKeybindExamples(fixtures)
+	captureKeybindParseErrors(fixtures)
+
+	if err := fixtures.WriteToFile(*outputDir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// modCombos is every modifier prefix combination the grammar accepts,
+// written in the canonical ctrl-alt-shift ordering Parse itself produces
+// when it strips prefixes left to right.
+var modCombos = []string{
+	"", "ctrl-", "alt-", "shift-",
+	"ctrl-alt-", "ctrl-shift-", "alt-shift-", "ctrl-alt-shift-",
+}
+
+// keybindBases is a representative sample of each base-key kind the
+// grammar supports: a lowercase letter, a punctuation rune, four named
+// keys spanning control keys and arrows, and the "[*]" wildcard.
+var keybindBases = []string{"a", ",", "tab", "enter", "bspace", "up", "[*]"}
+
+// captureKeybindMatrix emits one fixture per (modifier combo, base key)
+// pair -- "every combination" the request asks for -- recording Parse,
+// Sequences, and a Match self-check against the tea.KeyMsg keybind.KeyMsgFor
+// derives from the same chord.
+func captureKeybindMatrix(fs *capture.FixtureSet) {
+	for _, mod := range modCombos {
+		for _, base := range keybindBases {
+			expr := mod + base
+			fs.AddTestWithCategory(
+				"matrix_"+sanitizeName(expr), "matrix",
+				map[string]interface{}{"expression": expr},
+				keybindFixtureOutput(expr),
+			)
+		}
+	}
+}
+
+// captureKeybindExamples covers the specific expressions the request body
+// calls out by name, plus full f1..f12 coverage.
+func captureKeybindExamples(fs *capture.FixtureSet) {
+	examples := []string{
+		"ctrl-alt-a", "alt-,", "alt-[*]", "shift-tab", "alt-bspace",
+		"ctrl-up", "ctrl-down", "ctrl-left", "ctrl-right",
+		"shift-up", "shift-down", "shift-left", "shift-right",
+		"ctrl-z", "alt-enter", "ctrl--",
+	}
+	for i := 1; i <= 12; i++ {
+		examples = append(examples, fmt.Sprintf("f%d", i))
+	}
+
+	for _, expr := range examples {
+		fs.AddTestWithCategory(
+			"example_"+sanitizeName(expr), "example",
+			map[string]interface{}{"expression": expr},
+			keybindFixtureOutput(expr),
+		)
+	}
+}
+
+// captureKeybindParseErrors covers expressions Parse rejects, so a Rust
+// port's error path has fixtures too, not just its happy path.
+func captureKeybindParseErrors(fs *capture.FixtureSet) {
+	invalid := []string{"", "ctrl-", "foo-a", "ctrl-xyz", "alt-"}
+	for _, expr := range invalid {
+		fs.AddTestWithCategory(
+			"parse_error_"+sanitizeName(expr), "parse_error",
+			map[string]interface{}{"expression": expr},
+			keybindFixtureOutput(expr),
+		)
+	}
+}
+
+// keybindFixtureOutput runs expr through the full keybind pipeline,
+// recording every stage's result (or error) rather than stopping at the
+// first failure, since a later stage's absence is itself meaningful
+// conformance data (e.g. Sequences erroring for ctrl-[*] while Parse
+// still succeeds).
+func keybindFixtureOutput(expr string) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	chord, err := keybind.Parse(expr)
+	if err != nil {
+		out["parse_error"] = err.Error()
+		return out
+	}
+	out["chord"] = chordFixture(chord)
+
+	seqs, err := keybind.Sequences(chord)
+	if err != nil {
+		out["sequences_error"] = err.Error()
+	} else {
+		out["sequences"] = seqs
+	}
+
+	msg, ok := keybind.KeyMsgFor(chord)
+	if !ok {
+		out["canonical_key_msg"] = nil
+		out["matches_canonical"] = false
+		return out
+	}
+	out["canonical_key_msg"] = keyMsgFixture(msg)
+	out["matches_canonical"] = keybind.Match(chord, msg)
+
+	return out
+}
+
+func chordFixture(c keybind.KeyChord) map[string]interface{} {
+	return map[string]interface{}{
+		"ctrl":     c.Mods.Ctrl,
+		"alt":      c.Mods.Alt,
+		"shift":    c.Mods.Shift,
+		"named":    c.Named,
+		"rune":     string(c.Rune),
+		"wildcard": c.Wildcard,
+	}
+}
+
+func keyMsgFixture(msg tea.KeyMsg) map[string]interface{} {
+	return map[string]interface{}{
+		"type":   int(msg.Type),
+		"string": msg.String(),
+		"runes":  string(msg.Runes),
+		"alt":    msg.Alt,
+	}
+}
+
+func sanitizeName(expr string) string {
+	if expr == "" {
+		return "empty"
+	}
+	out := make([]rune, 0, len(expr))
+	for _, r := range expr {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			out = append(out, r)
+		case r == '-':
+			out = append(out, '_')
+		case r == '[' || r == ']' || r == '*':
+			out = append(out, 'w')
+		default:
+			out = append(out, 'x')
+		}
+	}
+	return string(out)
+}
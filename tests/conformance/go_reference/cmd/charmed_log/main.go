@@ -5,6 +5,7 @@ import (
 	"charmed_conformance/internal/capture"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/charmbracelet/log"
@@ -28,6 +29,9 @@ func main() {
 	// Capture level comparison tests
 	captureLevelComparisonTests(fixtures)
 
+	// Capture custom named level and slog interop tests
+	captureCustomLevelTests(fixtures)
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -169,15 +173,15 @@ func captureLevelComparisonTests(fs *capture.FixtureSet) {
 				fmt.Sprintf("level_compare_%s_vs_%s", lvl1.String(), lvl2.String()),
 				"unit",
 				map[string]interface{}{
-					"level1":       int(lvl1),
-					"level2":       int(lvl2),
-					"level1_name":  lvl1.String(),
-					"level2_name":  lvl2.String(),
+					"level1":      int(lvl1),
+					"level2":      int(lvl2),
+					"level1_name": lvl1.String(),
+					"level2_name": lvl2.String(),
 				},
 				map[string]interface{}{
-					"less_than":       i < j,
-					"greater_than":    i > j,
-					"equal":           i == j,
+					"less_than":                i < j,
+					"greater_than":             i > j,
+					"equal":                    i == j,
 					"level1_enabled_at_level2": lvl1 >= lvl2,
 				},
 			)
@@ -185,6 +189,78 @@ func captureLevelComparisonTests(fs *capture.FixtureSet) {
 	}
 }
 
+// captureCustomLevelTests models charmbracelet/log's inability to name
+// custom levels (its String() only knows its own five) by registering a
+// few via capture.RegisterLevel, then recording name-to-value parsing,
+// String()-style formatting, ordering against the five builtin levels,
+// and round-tripping against log/slog's own numeric scheme -- plus the
+// "below Debug"/"above Fatal" fallback formatting on its own, since that
+// algorithm applies to any unregistered value, not just the three named
+// here.
+func captureCustomLevelTests(fs *capture.FixtureSet) {
+	custom := []struct {
+		name  string
+		value int
+	}{
+		{"TRACE", -8},
+		{"NOTICE", 2},
+		{"CRITICAL", 12}, // shares FatalLevel's value; registration shadows it.
+	}
+	for _, c := range custom {
+		capture.RegisterLevel(c.name, c.value)
+	}
+
+	builtins := []log.Level{
+		log.DebugLevel, log.InfoLevel, log.WarnLevel, log.ErrorLevel, log.FatalLevel,
+	}
+
+	for _, c := range custom {
+		parsedValue, parsedOK := capture.LevelValue(c.name)
+
+		comparisons := make(map[string]interface{}, len(builtins))
+		for _, b := range builtins {
+			comparisons[b.String()] = map[string]interface{}{
+				"less_than":        c.value < int(b),
+				"greater_than":     c.value > int(b),
+				"equal":            c.value == int(b),
+				"enabled_at_level": c.value >= int(b),
+			}
+		}
+
+		fs.AddTestWithCategory(
+			fmt.Sprintf("custom_level_%s", sanitizeName(c.name)), "custom_level",
+			map[string]interface{}{
+				"name":  c.name,
+				"value": c.value,
+			},
+			map[string]interface{}{
+				"parsed_value":        parsedValue,
+				"parsed_ok":           parsedOK,
+				"string_name":         capture.LevelName(c.value),
+				"slog_string_name":    slog.Level(c.value).String(),
+				"builtin_comparisons": comparisons,
+			},
+		)
+	}
+
+	// The naming algorithm applied to values no registration covers: below
+	// the lowest builtin level, between two builtin levels, and above the
+	// highest.
+	fallbackValues := []int{-12, -9, -6, -1, 1, 3, 6, 10, 16, 20}
+	for _, v := range fallbackValues {
+		fs.AddTestWithCategory(
+			fmt.Sprintf("level_fallback_%d", v), "custom_level",
+			map[string]interface{}{
+				"value": v,
+			},
+			map[string]interface{}{
+				"string_name":      capture.LevelName(v),
+				"slog_string_name": slog.Level(v).String(),
+			},
+		)
+	}
+}
+
 // sanitizeName converts a string to a valid test name
 func sanitizeName(s string) string {
 	if s == "" {
@@ -3,9 +3,15 @@ package main
 
 import (
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/capture/fuzz"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
 )
 
 // Note: The Go glow library is primarily a CLI application that uses glamour
@@ -14,9 +20,22 @@ import (
 
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	format := flag.String("format", "json", "Fixture format: json, yaml, msgpack, cbor, or jsonl")
+	verify := flag.Bool("verify", false, "check captured output against the existing golden fixture file instead of overwriting it")
+	fuzzCount := flag.Int("fuzz-count", 50, "Number of property-generated cases to append")
+	fuzzSeed := flag.Int64("fuzz-seed", 1, "RNG seed for property-generated cases")
 	flag.Parse()
 
+	restoreEnv := seedDeterministicEnv()
+	defer restoreEnv()
+
 	fixtures := capture.NewFixtureSet("glow", "1.5.1")
+	fixtures.SetEnvironment(map[string]string{
+		"TERM":           "xterm-256color",
+		"COLORTERM":      "truecolor",
+		"NO_COLOR":       "unset",
+		"CLICOLOR_FORCE": "unset",
+	})
 
 	// Capture config builder tests
 	captureConfigTests(fixtures)
@@ -27,24 +46,95 @@ func main() {
 	// Capture style selection tests
 	captureStyleTests(fixtures)
 
-	if err := fixtures.WriteToFile(*outputDir); err != nil {
+	if err := fuzz.FuzzGlow(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *verify {
+		if err := verifyAgainstGolden(fixtures, *outputDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s matches golden fixtures\n", fixtures.Metadata.Crate)
+		return
+	}
+
+	if err := fixtures.WriteWithFormat(*outputDir, *format); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-// GlowInput represents input for glow reader tests
-type GlowInput struct {
-	Markdown string  `json:"markdown"`
-	Style    string  `json:"style"`
-	Width    *int    `json:"width,omitempty"`
-	Pager    bool    `json:"pager"`
+// seedDeterministicEnv pins the environment variables glamour's style and
+// color-profile auto-detection read (TERM, COLORTERM, NO_COLOR,
+// CLICOLOR_FORCE) so captured output doesn't depend on the shell the suite
+// happens to run in, returning a func that restores the prior environment.
+func seedDeterministicEnv() func() {
+	keys := []string{"TERM", "COLORTERM", "NO_COLOR", "CLICOLOR_FORCE"}
+	saved := make(map[string]*string, len(keys))
+	for _, k := range keys {
+		if v, ok := os.LookupEnv(k); ok {
+			saved[k] = &v
+		} else {
+			saved[k] = nil
+		}
+	}
+
+	os.Setenv("TERM", "xterm-256color")
+	os.Setenv("COLORTERM", "truecolor")
+	os.Unsetenv("NO_COLOR")
+	os.Unsetenv("CLICOLOR_FORCE")
+
+	return func() {
+		for k, v := range saved {
+			if v == nil {
+				os.Unsetenv(k)
+			} else {
+				os.Setenv(k, *v)
+			}
+		}
+	}
 }
 
-// GlowOutput represents output from glow reader tests
-type GlowOutput struct {
-	Output string `json:"output"`
-	Error  bool   `json:"error"`
+// verifyAgainstGolden loads the previously captured fixture file for fs's
+// crate from outputDir and reports a diff error if any test's
+// ExpectedOutput no longer matches, instead of silently overwriting it.
+func verifyAgainstGolden(fs *capture.FixtureSet, outputDir string) error {
+	path := filepath.Join(outputDir, fs.Metadata.Crate+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading golden fixtures: %w", err)
+	}
+
+	var golden capture.FixtureSet
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return fmt.Errorf("parsing golden fixtures: %w", err)
+	}
+
+	goldenByName := make(map[string]capture.TestFixture, len(golden.Tests))
+	for _, t := range golden.Tests {
+		goldenByName[t.Name] = t
+	}
+
+	var mismatches []string
+	for _, t := range fs.Tests {
+		g, ok := goldenByName[t.Name]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: not present in golden fixtures", t.Name))
+			continue
+		}
+		got, _ := json.Marshal(t.ExpectedOutput)
+		want, _ := json.Marshal(g.ExpectedOutput)
+		if string(got) != string(want) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: expected_output differs from golden", t.Name))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%d fixture(s) differ from golden:\n%s", len(mismatches), strings.Join(mismatches, "\n"))
+	}
+	return nil
 }
 
 func captureConfigTests(fs *capture.FixtureSet) {
@@ -54,18 +144,18 @@ func captureConfigTests(fs *capture.FixtureSet) {
 			"test_type": "config_defaults",
 		},
 		map[string]interface{}{
-			"default_pager":  true,
-			"default_width":  nil,
-			"default_style":  "dark",
+			"default_pager": true,
+			"default_width": nil,
+			"default_style": "dark",
 		},
 	)
 
 	// Test config builder methods
 	configBuilderTests := []struct {
-		name   string
-		pager  bool
-		width  *int
-		style  string
+		name  string
+		pager bool
+		width *int
+		style string
 	}{
 		{"config_pager_disabled", false, nil, "dark"},
 		{"config_width_80", true, intPtr(80), "dark"},
@@ -91,40 +181,86 @@ func captureConfigTests(fs *capture.FixtureSet) {
 	}
 }
 
-func captureReaderTests(fs *capture.FixtureSet) {
-	// Reader rendering tests - these would need glamour to actually render
-	// For now, we capture the expected behavior patterns
+// buildRenderer constructs the glamour.TermRenderer a given reader test case
+// would use: capture.RenderModeAuto lets glamour pick a style from the (seeded)
+// terminal background the way the real CLI does, capture.RenderModeNoTTY forces the
+// "notty" style regardless of the requested style to mirror glow falling
+// back to plain output when stdout isn't a terminal, and capture.RenderModeTTY (the
+// default) honors the requested style as-is.
+func buildRenderer(style string, width *int, mode capture.RenderMode) (*glamour.TermRenderer, error) {
+	var opts []glamour.TermRendererOption
+	switch mode {
+	case capture.RenderModeAuto:
+		opts = append(opts, glamour.WithAutoStyle())
+	case capture.RenderModeNoTTY:
+		opts = append(opts, glamour.WithStandardStyle(normalizeStyleName("notty")))
+	default:
+		opts = append(opts, glamour.WithStandardStyle(normalizeStyleName(style)))
+	}
+
+	w := 80
+	if width != nil {
+		w = *width
+	}
+	opts = append(opts, glamour.WithWordWrap(w))
 
+	return glamour.NewTermRenderer(opts...)
+}
+
+// normalizeStyleName maps the dash/underscore "no-tty"/"no_tty" spellings
+// glow accepts to glamour's actual "notty" style name.
+func normalizeStyleName(style string) string {
+	switch style {
+	case "no-tty", "no_tty":
+		return "notty"
+	default:
+		return style
+	}
+}
+
+func captureReaderTests(fs *capture.FixtureSet) {
 	readerTests := []struct {
 		name     string
 		markdown string
 		style    string
 		width    *int
+		mode     capture.RenderMode
 	}{
-		{"reader_basic_text", "Hello, World!", "dark", nil},
-		{"reader_heading", "# Main Heading", "dark", nil},
-		{"reader_bold_italic", "**bold** and *italic*", "dark", nil},
-		{"reader_code_block", "```rust\nfn main() {}\n```", "dark", nil},
-		{"reader_list", "- item 1\n- item 2\n- item 3", "dark", nil},
-		{"reader_width_80", "This is a long line that should wrap at the specified width.", "dark", intPtr(80)},
-		{"reader_style_ascii", "# ASCII Heading\n\nSome text.", "ascii", nil},
-		{"reader_style_light", "# Light Theme", "light", nil},
-		{"reader_empty", "", "dark", nil},
+		{"reader_basic_text", "Hello, World!", "dark", nil, capture.RenderModeTTY},
+		{"reader_heading", "# Main Heading", "dark", nil, capture.RenderModeTTY},
+		{"reader_bold_italic", "**bold** and *italic*", "dark", nil, capture.RenderModeTTY},
+		{"reader_code_block", "```rust\nfn main() {}\n```", "dark", nil, capture.RenderModeTTY},
+		{"reader_list", "- item 1\n- item 2\n- item 3", "dark", nil, capture.RenderModeTTY},
+		{"reader_width_80", "This is a long line that should wrap at the specified width.", "dark", intPtr(80), capture.RenderModeTTY},
+		{"reader_style_ascii", "# ASCII Heading\n\nSome text.", "ascii", nil, capture.RenderModeTTY},
+		{"reader_style_light", "# Light Theme", "light", nil, capture.RenderModeTTY},
+		{"reader_empty", "", "dark", nil, capture.RenderModeTTY},
+		{"reader_mode_auto", "# Auto Style\n\nPicked from the seeded terminal background.", "dark", nil, capture.RenderModeAuto},
+		{"reader_mode_no_tty", "# No TTY\n\nShould render without ANSI styling.", "dark", nil, capture.RenderModeNoTTY},
+		{"reader_style_no_tty_alias", "# No TTY Style Alias", "no-tty", nil, capture.RenderModeTTY},
 	}
 
 	for _, tc := range readerTests {
-		input := GlowInput{
-			Markdown: tc.markdown,
-			Style:    tc.style,
-			Width:    tc.width,
-			Pager:    false,
+		input := capture.GlowInput{
+			Markdown:   tc.markdown,
+			Style:      tc.style,
+			Width:      tc.width,
+			Pager:      false,
+			RenderMode: tc.mode,
+		}
+
+		var out string
+		renderer, err := buildRenderer(tc.style, tc.width, tc.mode)
+		if err == nil {
+			out, err = renderer.Render(tc.markdown)
 		}
-		// Note: actual output would be captured by running glamour
-		// For now, mark as needing Go runtime capture
+
 		output := map[string]interface{}{
-			"output":      "[needs Go capture]",
-			"error":       false,
-			"needs_runtime": true,
+			"output": out,
+			"error":  err != nil,
+		}
+		if err != nil {
+			output["error_msg"] = err.Error()
 		}
 		fs.AddTestWithCategory(tc.name, "unit", input, output)
 	}
@@ -3,8 +3,10 @@ package main
 
 import (
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/capture/fuzz"
 	"flag"
 	"fmt"
+	"math"
 	"os"
 
 	"github.com/charmbracelet/harmonica"
@@ -12,6 +14,8 @@ import (
 
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	fuzzCount := flag.Int("fuzz-count", 50, "Number of property-generated cases to append per input type")
+	fuzzSeed := flag.Int64("fuzz-seed", 1, "RNG seed for property-generated cases")
 	flag.Parse()
 
 	fixtures := capture.NewFixtureSet("harmonica", "0.2.0")
@@ -25,6 +29,31 @@ func main() {
 	// Capture FPS utility
 	captureFPSTests(fixtures)
 
+	// Capture closed-form analytic spring behaviors, independent of
+	// harmonica's Euler integrator
+	captureAnalyticSpringTests(fixtures)
+
+	// Capture the kinetic scroll composite simulation (friction inside
+	// bounds, spring pullback at the leading/trailing extents)
+	captureKineticScrollTests(fixtures)
+
+	// Capture projectile motion with linear drag, which harmonica's own
+	// Projectile does not model
+	captureDraggedProjectileTests(fixtures)
+
+	// Capture a spring driven with wrap-around, shortest-path angular
+	// semantics
+	captureAngularSpringTests(fixtures)
+
+	if err := fuzz.FuzzSpring(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := fuzz.FuzzProjectile(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -38,12 +67,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_default_step", "unit",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   1.0,
+				Frequency:  6.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -58,12 +87,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(1.0, 1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_at_target", "unit",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   1.0,
+				Frequency:  6.0,
+				Damping:    1.0,
 				CurrentPos: 1.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -79,12 +108,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_with_velocity", "unit",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   1.0,
+				Frequency:  6.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   5.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -99,12 +128,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithNotes("spring_underdamped",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   0.3,
+				Frequency:  6.0,
+				Damping:    0.3,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -120,12 +149,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithNotes("spring_overdamped",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   2.0,
+				Frequency:  6.0,
+				Damping:    2.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -141,12 +170,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithNotes("spring_critically_damped",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   1.0,
+				Frequency:  6.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -162,12 +191,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_high_frequency", "unit",
 			capture.SpringInput{
-				Frequency: 15.0,
-				Damping:   1.0,
+				Frequency:  15.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -182,12 +211,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, 1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_low_frequency", "unit",
 			capture.SpringInput{
-				Frequency: 2.0,
-				Damping:   1.0,
+				Frequency:  2.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -226,12 +255,12 @@ func captureSpringTests(fs *capture.FixtureSet) {
 		pos, vel := spring.Update(0.0, -1.0, 1.0/60.0)
 		fs.AddTestWithCategory("spring_negative_target", "unit",
 			capture.SpringInput{
-				Frequency: 6.0,
-				Damping:   1.0,
+				Frequency:  6.0,
+				Damping:    1.0,
 				CurrentPos: 0.0,
 				TargetPos:  -1.0,
 				Velocity:   0.0,
-				DeltaTime:  1.0/60.0,
+				DeltaTime:  1.0 / 60.0,
 			},
 			capture.SpringOutput{
 				NewPos:      pos,
@@ -239,6 +268,84 @@ func captureSpringTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 11: Sleep/rest-state detection, using squared offset and
+	// velocity thresholds rather than tick-count or equality checks.
+	{
+		const (
+			offsetThreshold   = 1.0 / 3840.0
+			velocityThreshold = 0.01
+		)
+		sleepCases := []struct {
+			name      string
+			frequency float64
+			damping   float64
+			maxFrames int
+			notes     string
+		}{
+			{"spring_sleep_stiff_snappy", 30.0, 1.0, 600,
+				"Stiff, critically damped spring settles in very few frames"},
+			{"spring_sleep_slow_oscillating", 2.0, 0.4, 600,
+				"Slow, under-damped spring oscillates through many frames before settling"},
+			{"spring_sleep_never_settles_timeout", 2.0, 0.4, 5,
+				"Max-frame budget far too small for this spring to settle: pins down timeout semantics"},
+		}
+		for _, c := range sleepCases {
+			out := simulateSpringSleep(c.frequency, c.damping, 0.0, 1.0, 0.0, 1.0/60.0,
+				offsetThreshold, velocityThreshold, c.maxFrames)
+			fs.AddTestWithNotes(c.name,
+				capture.SpringSleepInput{
+					Frequency:         c.frequency,
+					Damping:           c.damping,
+					CurrentPos:        0.0,
+					TargetPos:         1.0,
+					Velocity:          0.0,
+					DeltaTime:         1.0 / 60.0,
+					OffsetThreshold:   offsetThreshold,
+					VelocityThreshold: velocityThreshold,
+					MaxFrames:         c.maxFrames,
+				},
+				out,
+				c.notes,
+			)
+		}
+	}
+}
+
+// simulateSpringSleep runs a spring from (pos0, vel0) toward target until
+// its squared offset from target and squared velocity both drop below
+// their thresholds ("at rest"), or until maxFrames elapses first.
+func simulateSpringSleep(frequency, damping, pos0, target, vel0, dt, offsetThreshold, velocityThreshold float64, maxFrames int) capture.SpringSleepOutput {
+	spring := harmonica.NewSpring(harmonica.FPS(int(1/dt)), frequency, damping)
+	pos := pos0
+	vel := vel0
+	offsetSq := offsetThreshold * offsetThreshold
+	velSq := velocityThreshold * velocityThreshold
+
+	trajectory := []capture.SpringSleepFrame{{Pos: pos, Vel: vel}}
+	for frame := 1; frame <= maxFrames; frame++ {
+		pos, vel = spring.Update(pos, vel, target)
+		trajectory = append(trajectory, capture.SpringSleepFrame{Pos: pos, Vel: vel})
+
+		offset := pos - target
+		if offset*offset < offsetSq && vel*vel < velSq {
+			return capture.SpringSleepOutput{
+				Settled:       true,
+				SleepFrame:    frame,
+				FinalPos:      pos,
+				FinalVelocity: vel,
+				Trajectory:    trajectory,
+			}
+		}
+	}
+
+	return capture.SpringSleepOutput{
+		Settled:       false,
+		SleepFrame:    maxFrames,
+		FinalPos:      pos,
+		FinalVelocity: vel,
+		Trajectory:    trajectory,
+	}
 }
 
 func captureProjectileTests(fs *capture.FixtureSet) {
@@ -253,7 +360,7 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 				X: 0, Y: 10, Z: 0,
 				VelX: 0, VelY: 0, VelZ: 0,
 				Gravity:   9.81,
-				DeltaTime: 1.0/60.0,
+				DeltaTime: 1.0 / 60.0,
 			},
 			capture.ProjectileOutput{
 				X: pos.X, Y: pos.Y, Z: pos.Z,
@@ -274,7 +381,7 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 				X: 0, Y: 10, Z: 0,
 				VelX: 5, VelY: 0, VelZ: 0,
 				Gravity:   9.81,
-				DeltaTime: 1.0/60.0,
+				DeltaTime: 1.0 / 60.0,
 			},
 			capture.ProjectileOutput{
 				X: pos.X, Y: pos.Y, Z: pos.Z,
@@ -295,7 +402,7 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 				X: 0, Y: 0, Z: 0,
 				VelX: 0, VelY: 10, VelZ: 0,
 				Gravity:   9.81,
-				DeltaTime: 1.0/60.0,
+				DeltaTime: 1.0 / 60.0,
 			},
 			capture.ProjectileOutput{
 				X: pos.X, Y: pos.Y, Z: pos.Z,
@@ -316,7 +423,7 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 				X: 1, Y: 2, Z: 3,
 				VelX: 1, VelY: 2, VelZ: 3,
 				Gravity:   9.81,
-				DeltaTime: 1.0/60.0,
+				DeltaTime: 1.0 / 60.0,
 			},
 			capture.ProjectileOutput{
 				X: pos.X, Y: pos.Y, Z: pos.Z,
@@ -341,10 +448,10 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 		}
 		fs.AddTestWithNotes("projectile_trajectory_10_steps",
 			map[string]interface{}{
-				"start_pos":  []float64{0, 0, 0},
-				"start_vel":  []float64{10, 15, 0},
-				"gravity":    9.81,
-				"steps":      10,
+				"start_pos": []float64{0, 0, 0},
+				"start_vel": []float64{10, 15, 0},
+				"gravity":   9.81,
+				"steps":     10,
 			},
 			steps,
 			"Tracks projectile position over 10 simulation steps",
@@ -352,6 +459,356 @@ func captureProjectileTests(fs *capture.FixtureSet) {
 	}
 }
 
+// draggedProjectileTrajectory runs n frames of StepDraggedProjectile from
+// the given starting state and returns the per-frame trace.
+func draggedProjectileTrajectory(start capture.DraggedProjectileInput, n int) []capture.DraggedProjectileOutput {
+	frames := make([]capture.DraggedProjectileOutput, n)
+	in := start
+	for i := 0; i < n; i++ {
+		out := capture.StepDraggedProjectile(in)
+		frames[i] = out
+		in.X, in.Y, in.Z = out.X, out.Y, out.Z
+		in.VelX, in.VelY, in.VelZ = out.VelX, out.VelY, out.VelZ
+	}
+	return frames
+}
+
+func captureDraggedProjectileTests(fs *capture.FixtureSet) {
+	// Test 1: Vertical drop approaching terminal velocity v_t = g/k
+	{
+		in := capture.DraggedProjectileInput{
+			X: 0, Y: 1000, Z: 0,
+			VelX: 0, VelY: 0, VelZ: 0,
+			Gravity:   9.81,
+			Drag:      0.5,
+			DeltaTime: 1.0 / 60.0,
+		}
+		frames := draggedProjectileTrajectory(in, 300)
+		fs.AddTestWithNotes("dragged_projectile_terminal_velocity",
+			map[string]interface{}{
+				"start": in,
+				"steps": 300,
+			},
+			map[string]interface{}{
+				"frames":            frames,
+				"terminal_velocity": -in.Gravity / in.Drag,
+			},
+			"Vertical drop under linear drag should asymptotically approach v_t = -g/k and stop accelerating",
+		)
+	}
+
+	// Test 2: Horizontal throw -- drag pulls horizontal velocity toward
+	// zero while gravity still accumulates downward velocity unimpeded by
+	// anything but drag
+	{
+		in := capture.DraggedProjectileInput{
+			X: 0, Y: 10, Z: 0,
+			VelX: 20, VelY: 0, VelZ: 0,
+			Gravity:   9.81,
+			Drag:      0.8,
+			DeltaTime: 1.0 / 60.0,
+		}
+		frames := draggedProjectileTrajectory(in, 60)
+		fs.AddTestWithNotes("dragged_projectile_horizontal_throw",
+			map[string]interface{}{
+				"start": in,
+				"steps": 60,
+			},
+			frames,
+			"Horizontal velocity decays toward zero under drag while vertical velocity keeps accumulating",
+		)
+	}
+
+	// Test 3: 3D arc with drag, producing a shorter range than the
+	// drag-free case
+	{
+		dragFree := capture.DraggedProjectileInput{
+			X: 0, Y: 0, Z: 0,
+			VelX: 15, VelY: 10, VelZ: 5,
+			Gravity:   9.81,
+			Drag:      0,
+			DeltaTime: 1.0 / 60.0,
+		}
+		dragged := dragFree
+		dragged.Drag = 0.3
+		fs.AddTestWithNotes("dragged_projectile_3d_arc_shorter_range",
+			map[string]interface{}{
+				"drag_free_start": dragFree,
+				"dragged_start":   dragged,
+				"steps":           90,
+			},
+			map[string]interface{}{
+				"drag_free_frames": draggedProjectileTrajectory(dragFree, 90),
+				"dragged_frames":   draggedProjectileTrajectory(dragged, 90),
+			},
+			"With drag, horizontal range over the same number of frames is shorter than the drag-free case",
+		)
+	}
+
+	// Test 4: k=0 degenerate case must match the existing
+	// projectile_freefall fixture exactly
+	{
+		in := capture.DraggedProjectileInput{
+			X: 0, Y: 10, Z: 0,
+			VelX: 0, VelY: 0, VelZ: 0,
+			Gravity:   9.81,
+			Drag:      0,
+			DeltaTime: 1.0 / 60.0,
+		}
+		fs.AddTestWithNotes("dragged_projectile_zero_drag_matches_freefall",
+			in, capture.StepDraggedProjectile(in),
+			"With drag coefficient 0 this must match the projectile_freefall fixture from captureProjectileTests exactly",
+		)
+	}
+}
+
+// angularSleepOffsetThreshold and angularSleepVelocityThreshold are the
+// rest-state thresholds for an angular spring, expressed in radians and
+// radians/second respectively (0.01 and 0.1 degrees).
+const (
+	angularSleepOffsetThreshold   = 0.01 * math.Pi / 180.0
+	angularSleepVelocityThreshold = 0.1 * math.Pi / 180.0
+)
+
+// stepAngularSpring advances one frame of an angular spring, feeding the
+// underlying harmonica.Spring the shortest-path delta to target rather
+// than the raw numeric difference, then re-wrapping the result into
+// [0, 2π). angularVelocity is the caller's own running velocity state --
+// harmonica.Spring carries no velocity of its own between Update calls.
+func stepAngularSpring(spring harmonica.Spring, currentAngle, angularVelocity, targetAngle float64) capture.AngularSpringOutput {
+	delta := capture.WrapDeltaToShortestPath(currentAngle, targetAngle)
+	localTarget := currentAngle + delta
+	pos, vel := spring.Update(currentAngle, angularVelocity, localTarget)
+	return capture.AngularSpringOutput{
+		NewAngle:           capture.WrapAngle(pos),
+		NewAngularVelocity: vel,
+		WrappedDelta:       delta,
+	}
+}
+
+func captureAngularSpringTests(fs *capture.FixtureSet) {
+	// Test 1: 350 degrees to 10 degrees must go the short way (+20
+	// degrees), not the long way around (-340 degrees)
+	{
+		currentAngle := 350.0 * math.Pi / 180.0
+		targetAngle := 10.0 * math.Pi / 180.0
+		spring := harmonica.NewSpring(harmonica.FPS(60), 6.0, 1.0)
+		out := stepAngularSpring(spring, currentAngle, 0.0, targetAngle)
+		fs.AddTestWithNotes("angular_spring_wrap_short_path",
+			capture.AngularSpringInput{
+				Frequency:       6.0,
+				Damping:         1.0,
+				CurrentAngle:    currentAngle,
+				TargetAngle:     targetAngle,
+				AngularVelocity: 0.0,
+				DeltaTime:       1.0 / 60.0,
+			},
+			out,
+			"350 degrees to 10 degrees must take the +20 degree short path, not the -340 degree long path; wrapped_delta should be +20 degrees in radians",
+		)
+	}
+
+	// Test 2: 180 degree ambiguity -- both directions are equally short,
+	// so this pins down the tie-breaking choice math.Mod's sign
+	// convention makes (here, the positive direction).
+	{
+		currentAngle := 0.0
+		targetAngle := math.Pi
+		spring := harmonica.NewSpring(harmonica.FPS(60), 6.0, 1.0)
+		out := stepAngularSpring(spring, currentAngle, 0.0, targetAngle)
+		fs.AddTestWithNotes("angular_spring_180_degree_ambiguity",
+			capture.AngularSpringInput{
+				Frequency:       6.0,
+				Damping:         1.0,
+				CurrentAngle:    currentAngle,
+				TargetAngle:     targetAngle,
+				AngularVelocity: 0.0,
+				DeltaTime:       1.0 / 60.0,
+			},
+			out,
+			"At exactly 180 degrees both directions are equally short; this fixture documents which way this implementation breaks the tie",
+		)
+	}
+
+	// Test 3: Multi-step settling of an under-damped angular spring that
+	// crosses the 0/2π seam.
+	{
+		currentAngle := 350.0 * math.Pi / 180.0
+		targetAngle := 10.0 * math.Pi / 180.0
+		spring := harmonica.NewSpring(harmonica.FPS(60), 6.0, 0.5)
+		angle := currentAngle
+		angularVelocity := 0.0
+		const maxFrames = 120
+		steps := make([]capture.AngularSpringOutput, 0, maxFrames)
+		settled := false
+		settleFrame := maxFrames
+		for frame := 1; frame <= maxFrames; frame++ {
+			out := stepAngularSpring(spring, angle, angularVelocity, targetAngle)
+			angle = out.NewAngle
+			angularVelocity = out.NewAngularVelocity
+			steps = append(steps, out)
+			if !settled {
+				offset := capture.WrapDeltaToShortestPath(angle, targetAngle)
+				if offset*offset < angularSleepOffsetThreshold*angularSleepOffsetThreshold &&
+					out.NewAngularVelocity*out.NewAngularVelocity < angularSleepVelocityThreshold*angularSleepVelocityThreshold {
+					settled = true
+					settleFrame = frame
+				}
+			}
+		}
+		fs.AddTestWithNotes("angular_spring_crosses_seam_multi_step",
+			map[string]interface{}{
+				"frequency":    6.0,
+				"damping":      0.5,
+				"start_angle":  currentAngle,
+				"target_angle": targetAngle,
+				"max_frames":   maxFrames,
+			},
+			map[string]interface{}{
+				"steps":        steps,
+				"settled":      settled,
+				"settle_frame": settleFrame,
+			},
+			"Under-damped angular spring crossing the 0/2pi seam while settling toward target",
+		)
+	}
+}
+
+// analyticDampingCases are the three branches AnalyticSpring's closed
+// form takes, at the same angular frequency captureSpringTests' Euler
+// cases use so the two can be compared qualitatively.
+var analyticDampingCases = []struct {
+	name string
+	zeta float64
+}{
+	{"overdamped", 2.0},
+	{"critically_damped", 1.0},
+	{"underdamped", 0.3},
+}
+
+// analyticDeltaTimes are the frame rates captureAnalyticSpringTests
+// evaluates every damping case at, so the Rust port can be checked at
+// each without Euler integrator drift confounding the comparison.
+var analyticDeltaTimes = []float64{1.0 / 30, 1.0 / 60, 1.0 / 240}
+
+func captureAnalyticSpringTests(fs *capture.FixtureSet) {
+	const omega = 6.0
+
+	// Single-step fixtures across every damping case and frame rate.
+	for _, dc := range analyticDampingCases {
+		for _, dt := range analyticDeltaTimes {
+			in := capture.AnalyticSpringInput{
+				AngularFreq:  omega,
+				DampingRatio: dc.zeta,
+				CurrentPos:   0.0,
+				Velocity:     0.0,
+				TargetPos:    1.0,
+				DeltaTime:    dt,
+			}
+			fs.AddTestWithCategory(
+				fmt.Sprintf("analytic_spring_%s_dt_%d", dc.name, int(1/dt)),
+				"analytic_spring", in, capture.AnalyticSpring(in),
+			)
+		}
+	}
+
+	// Multi-step convergence: feed each step's output back in as the next
+	// step's current position/velocity, the way captureSpringTests'
+	// spring_convergence_10_steps does for the Euler integrator.
+	for _, dc := range analyticDampingCases {
+		pos, vel := 0.0, 0.0
+		const dt = 1.0 / 60.0
+		steps := make([]capture.AnalyticSpringOutput, 10)
+		for i := 0; i < 10; i++ {
+			out := capture.AnalyticSpring(capture.AnalyticSpringInput{
+				AngularFreq:  omega,
+				DampingRatio: dc.zeta,
+				CurrentPos:   pos,
+				Velocity:     vel,
+				TargetPos:    1.0,
+				DeltaTime:    dt,
+			})
+			pos, vel = out.NewPos, out.NewVelocity
+			steps[i] = out
+		}
+		fs.AddTestWithNotes(
+			fmt.Sprintf("analytic_spring_convergence_%s_10_steps", dc.name),
+			map[string]interface{}{
+				"angular_freq":  omega,
+				"damping_ratio": dc.zeta,
+				"start_pos":     0.0,
+				"target_pos":    1.0,
+				"delta_time":    dt,
+				"steps":         10,
+			},
+			steps,
+			"Tracks the closed-form spring's position over 10 steps, each step's output feeding the next step's input",
+		)
+	}
+
+	// Edge case: zeta=0 is a pure undamped oscillator, which must
+	// conserve mechanical energy (0.5*v^2 + 0.5*omega^2*x^2) to within a
+	// tight epsilon at every step, unlike the Euler integrator which
+	// drifts.
+	{
+		const dt = 1.0 / 60.0
+		pos, vel := 0.0, 2.0
+		target := 1.0
+		energies := make([]float64, 20)
+		steps := make([]capture.AnalyticSpringOutput, 20)
+		for i := 0; i < 20; i++ {
+			out := capture.AnalyticSpring(capture.AnalyticSpringInput{
+				AngularFreq:  omega,
+				DampingRatio: 0.0,
+				CurrentPos:   pos,
+				Velocity:     vel,
+				TargetPos:    target,
+				DeltaTime:    dt,
+			})
+			pos, vel = out.NewPos, out.NewVelocity
+			steps[i] = out
+			offset := pos - target
+			energies[i] = 0.5*vel*vel + 0.5*omega*omega*offset*offset
+		}
+		fs.AddTestWithNotes(
+			"analytic_spring_undamped_energy_conservation",
+			map[string]interface{}{
+				"angular_freq":  omega,
+				"damping_ratio": 0.0,
+				"start_pos":     0.0,
+				"start_vel":     2.0,
+				"target_pos":    target,
+				"delta_time":    dt,
+				"steps":         20,
+			},
+			map[string]interface{}{
+				"steps":    steps,
+				"energies": energies,
+			},
+			"An undamped (zeta=0) spring is a pure oscillator: mechanical energy should stay constant across every step, to within floating-point precision",
+		)
+	}
+
+	// Edge case: a near-zero angular frequency spring has essentially no
+	// restoring force, so it should evolve as free motion at constant
+	// velocity regardless of damping ratio.
+	{
+		in := capture.AnalyticSpringInput{
+			AngularFreq:  1e-10,
+			DampingRatio: 0.5,
+			CurrentPos:   0.0,
+			Velocity:     3.0,
+			TargetPos:    100.0,
+			DeltaTime:    1.0 / 60.0,
+		}
+		fs.AddTestWithNotes(
+			"analytic_spring_near_zero_frequency",
+			in, capture.AnalyticSpring(in),
+			"With angular frequency near zero there is effectively no restoring force, so position should advance at the constant initial velocity",
+		)
+	}
+}
+
 func captureFPSTests(fs *capture.FixtureSet) {
 	// Test FPS utility function
 	fpsValues := []int{30, 60, 120, 144, 240}
@@ -363,3 +820,130 @@ func captureFPSTests(fs *capture.FixtureSet) {
 		)
 	}
 }
+
+// kineticScrollSettleVelocity and kineticScrollSettlePosition are the
+// thresholds simulateKineticScroll uses to decide a frame has "settled":
+// velocity alone inside the scrollable bounds, velocity and distance to
+// the anchored boundary once a spring pullback is active.
+const (
+	kineticScrollSettleVelocity = 0.5
+	kineticScrollSettlePosition = 0.5
+	kineticScrollMaxFrames      = 300
+)
+
+// simulateKineticScroll drives the composite kinetic-scroll simulation:
+// friction decay while leading<=x<=trailing, switching to a harmonica
+// spring anchored at whichever boundary x crosses, carrying the
+// crossing-frame velocity over as the spring's initial velocity. It
+// returns the full per-frame trace and whether the simulation settled
+// within kineticScrollMaxFrames.
+func simulateKineticScroll(leading, trailing, x0, v0, drag, springOmega, springZeta, dt float64) capture.KineticScrollOutput {
+	frames := make([]capture.KineticScrollFrame, 0, kineticScrollMaxFrames)
+
+	phase := "friction"
+	frictionX0, frictionV0 := x0, v0
+	frictionStart := 0
+
+	var spring harmonica.Spring
+	var springBoundary float64
+
+	x, v := x0, v0
+	frames = append(frames, capture.KineticScrollFrame{X: x, V: v, Phase: phase})
+
+	settled := false
+	for frame := 1; frame < kineticScrollMaxFrames && !settled; frame++ {
+		switch phase {
+		case "friction":
+			t := float64(frame-frictionStart) * dt
+			x, v = capture.FrictionPosition(frictionX0, frictionV0, drag, t)
+
+			switch {
+			case x > trailing:
+				phase = "spring_trailing"
+				springBoundary = trailing
+				spring = harmonica.NewSpring(harmonica.FPS(int(1/dt)), springOmega, springZeta)
+			case x < leading:
+				phase = "spring_leading"
+				springBoundary = leading
+				spring = harmonica.NewSpring(harmonica.FPS(int(1/dt)), springOmega, springZeta)
+			case math.Abs(v) < kineticScrollSettleVelocity:
+				settled = true
+			}
+
+		default: // "spring_trailing", "spring_leading"
+			x, v = spring.Update(x, v, springBoundary)
+			if math.Abs(v) < kineticScrollSettleVelocity && math.Abs(x-springBoundary) < kineticScrollSettlePosition {
+				settled = true
+			}
+		}
+
+		frames = append(frames, capture.KineticScrollFrame{X: x, V: v, Phase: phase})
+	}
+
+	return capture.KineticScrollOutput{Frames: frames, Settled: settled}
+}
+
+// captureKineticScrollTests covers the four scenarios a kinetic scroll
+// view must get right: decelerating to rest inside its bounds, flicking
+// past each boundary and springing back (critically damped and
+// under-damped), and an initial velocity aimed straight through the
+// leading boundary.
+func captureKineticScrollTests(fs *capture.FixtureSet) {
+	const dt = 1.0 / 60.0
+
+	cases := []struct {
+		name                    string
+		leading, trailing       float64
+		x0, v0, drag            float64
+		springOmega, springZeta float64
+		notes                   string
+	}{
+		{
+			name:    "flick_inside_bounds_decelerates_to_rest",
+			leading: 0, trailing: 1000,
+			x0: 500, v0: 300, drag: 0.05,
+			springOmega: 6.0, springZeta: 1.0,
+			notes: "Flick starts well inside the scrollable range and decays to rest without ever reaching a boundary",
+		},
+		{
+			name:    "flick_past_trailing_springs_back",
+			leading: 0, trailing: 1000,
+			x0: 950, v0: 600, drag: 0.3,
+			springOmega: 6.0, springZeta: 1.0,
+			notes: "Flick carries position past the trailing boundary; a critically damped spring pulls it back without overshoot",
+		},
+		{
+			name:    "flick_past_trailing_overshoots_then_settles",
+			leading: 0, trailing: 1000,
+			x0: 950, v0: 600, drag: 0.3,
+			springOmega: 6.0, springZeta: 0.4,
+			notes: "Same flick as flick_past_trailing_springs_back, but an under-damped boundary spring overshoots and oscillates before settling",
+		},
+		{
+			name:    "opposite_velocity_at_leading_boundary",
+			leading: 0, trailing: 1000,
+			x0: 20, v0: -500, drag: 0.3,
+			springOmega: 6.0, springZeta: 1.0,
+			notes: "Initial velocity points straight through the leading boundary from just inside it",
+		},
+	}
+
+	for _, tc := range cases {
+		out := simulateKineticScroll(tc.leading, tc.trailing, tc.x0, tc.v0, tc.drag, tc.springOmega, tc.springZeta, dt)
+		fs.AddTestWithNotes(
+			fmt.Sprintf("kinetic_scroll_%s", tc.name),
+			map[string]interface{}{
+				"leading":      tc.leading,
+				"trailing":     tc.trailing,
+				"start_pos":    tc.x0,
+				"start_vel":    tc.v0,
+				"drag":         tc.drag,
+				"spring_omega": tc.springOmega,
+				"spring_zeta":  tc.springZeta,
+				"delta_time":   dt,
+			},
+			out,
+			tc.notes,
+		)
+	}
+}
@@ -3,9 +3,16 @@ package main
 
 import (
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/fuzzyfilter"
+	"charmed_conformance/internal/optsource"
+	"charmed_conformance/internal/themes"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 )
@@ -40,12 +47,48 @@ func main() {
 	// Capture theme tests
 	captureThemeTests(fixtures)
 
+	// Capture canonical INI/TOML theme file tests
+	if err := captureThemeFileTests(fixtures); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Capture select/multi-select fuzzy-filter tests
+	captureSelectFilterTests(fixtures)
+
+	// Capture delimited and stdin-driven option source tests
+	captureSelectDelimited(fixtures)
+	captureSelectStdin(fixtures)
+	captureSelectIfOne(fixtures)
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// captureFormSteps drives form through steps via capture.FormDriver,
+// reading back the fields tagged with keys into each step's Values, and
+// adds a fixture recording every step's rendered view, values, and
+// completion state -- so a Rust port can be checked against byte-identical
+// frames for the same input stream rather than just matching initial state.
+func captureFormSteps(fs *capture.FixtureSet, name, category string, form *huh.Form, keys, steps []string, baseInput map[string]interface{}) {
+	driver := capture.NewFormDriver(form, keys...)
+	results := driver.Run(steps)
+
+	input := make(map[string]interface{}, len(baseInput)+1)
+	for k, v := range baseInput {
+		input[k] = v
+	}
+	input["steps"] = steps
+
+	fs.AddTestWithCategory(name, category, input,
+		map[string]interface{}{
+			"step_results": results,
+		},
+	)
+}
+
 func captureInputFieldTests(fs *capture.FixtureSet) {
 	// Test 1: Basic input field
 	{
@@ -169,6 +212,26 @@ func captureInputFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 7: Keystroke-level capture -- typing "hi" into a focused input.
+	{
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Enter name").
+					Key("name").
+					Value(&value),
+			),
+		)
+		captureFormSteps(fs, "input_keystroke_capture", "driven", form,
+			[]string{"name"},
+			[]string{"h", "i"},
+			map[string]interface{}{
+				"title": "Enter name",
+			},
+		)
+	}
 }
 
 func captureTextFieldTests(fs *capture.FixtureSet) {
@@ -253,6 +316,26 @@ func captureTextFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 5: Keystroke-level capture -- typing across a line break.
+	{
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewText().
+					Title("Description").
+					Key("description").
+					Value(&value),
+			),
+		)
+		captureFormSteps(fs, "text_keystroke_capture", "driven", form,
+			[]string{"description"},
+			[]string{"h", "i", "enter", "t", "h", "e", "r", "e"},
+			map[string]interface{}{
+				"title": "Description",
+			},
+		)
+	}
 }
 
 func captureSelectFieldTests(fs *capture.FixtureSet) {
@@ -362,6 +445,32 @@ func captureSelectFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 5: Keystroke-level capture -- navigating down then selecting.
+	{
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Choose an option").
+					Key("option").
+					Options(
+						huh.NewOption("Option A", "a"),
+						huh.NewOption("Option B", "b"),
+						huh.NewOption("Option C", "c"),
+					).
+					Value(&value),
+			),
+		)
+		captureFormSteps(fs, "select_keystroke_capture", "driven", form,
+			[]string{"option"},
+			[]string{"down", "down", "enter"},
+			map[string]interface{}{
+				"title":   "Choose an option",
+				"options": []string{"a", "b", "c"},
+			},
+		)
+	}
 }
 
 func captureMultiSelectFieldTests(fs *capture.FixtureSet) {
@@ -468,6 +577,32 @@ func captureMultiSelectFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 5: Keystroke-level capture -- toggling two items then confirming.
+	{
+		var values []string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewMultiSelect[string]().
+					Title("Select items").
+					Key("items").
+					Options(
+						huh.NewOption("Item A", "a"),
+						huh.NewOption("Item B", "b"),
+						huh.NewOption("Item C", "c"),
+					).
+					Value(&values),
+			),
+		)
+		captureFormSteps(fs, "multiselect_keystroke_capture", "driven", form,
+			[]string{"items"},
+			[]string{"space", "down", "space", "enter"},
+			map[string]interface{}{
+				"title":   "Select items",
+				"options": []string{"a", "b", "c"},
+			},
+		)
+	}
 }
 
 func captureConfirmFieldTests(fs *capture.FixtureSet) {
@@ -553,6 +688,26 @@ func captureConfirmFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 5: Keystroke-level capture -- toggling the highlighted choice.
+	{
+		var value bool
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title("Continue?").
+					Key("continue").
+					Value(&value),
+			),
+		)
+		captureFormSteps(fs, "confirm_keystroke_capture", "driven", form,
+			[]string{"continue"},
+			[]string{"left", "right", "enter"},
+			map[string]interface{}{
+				"title": "Continue?",
+			},
+		)
+	}
 }
 
 func captureNoteFieldTests(fs *capture.FixtureSet) {
@@ -610,6 +765,28 @@ func captureNoteFieldTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 4: Keystroke-level capture -- advancing past the note with enter.
+	{
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewNote().
+					Title("Step 1").
+					Description("First step description").
+					Next(true).
+					NextLabel("Continue"),
+			),
+		)
+		captureFormSteps(fs, "note_keystroke_capture", "driven", form,
+			nil,
+			[]string{"enter"},
+			map[string]interface{}{
+				"title":      "Step 1",
+				"next":       true,
+				"next_label": "Continue",
+			},
+		)
+	}
 }
 
 func captureValidationTests(fs *capture.FixtureSet) {
@@ -709,6 +886,34 @@ func captureValidationTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test 4: Keystroke-level capture -- typing a too-short password then
+	// tabbing away, so the rendered view includes the validation error.
+	{
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Password").
+					Key("password").
+					Validate(func(s string) error {
+						if len(s) < 8 {
+							return fmt.Errorf("password must be at least 8 characters")
+						}
+						return nil
+					}).
+					Value(&value),
+			),
+		)
+		captureFormSteps(fs, "validation_keystroke_capture", "driven", form,
+			[]string{"password"},
+			[]string{"s", "h", "o", "r", "t", "tab"},
+			map[string]interface{}{
+				"title":      "Password",
+				"min_length": 8,
+			},
+		)
+	}
 }
 
 func captureThemeTests(fs *capture.FixtureSet) {
@@ -755,4 +960,268 @@ func captureThemeTests(fs *capture.FixtureSet) {
 			},
 		)
 	}
+
+	// Test: Keystroke-level capture -- confirms theme styling survives a
+	// real Update/View round trip, not just construction.
+	{
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Name").
+					Key("name").
+					Value(&value),
+			),
+		).WithTheme(huh.ThemeCharm())
+		captureFormSteps(fs, "themed_form_keystroke_capture", "driven", form,
+			[]string{"name"},
+			[]string{"a", "v", "a"},
+			map[string]interface{}{
+				"theme": "charm",
+			},
+		)
+	}
+}
+
+// selectFilterResultFixtures converts fuzzyfilter.Result slices into the
+// plain JSON shape a fixture needs: ordered index/score/positions per
+// surviving candidate.
+func selectFilterResultFixtures(results []fuzzyfilter.Result) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(results))
+	for i, r := range results {
+		out[i] = map[string]interface{}{
+			"index":     r.Index,
+			"candidate": r.Candidate,
+			"score":     r.Score,
+			"positions": r.Positions,
+		}
+	}
+	return out
+}
+
+// captureSelectFilterTests exercises huh Select/MultiSelect option filtering
+// through the fzf v1 and v2 algorithms, recording the resulting order and
+// per-candidate score so a Rust port's fuzzy matcher can be validated
+// value-for-value against both algorithm versions.
+func captureSelectFilterTests(fs *capture.FixtureSet) {
+	filterCases := []struct {
+		name       string
+		pattern    string
+		candidates []string
+	}{
+		{"basic", "iggy", []string{"instance_group-1.yaml", "iggy-pop.txt", "other-file.go"}},
+		{"case_smart_lower", "us", []string{"us-east-1", "US-WEST-2", "eu-central-1"}},
+		{"case_smart_upper", "US", []string{"us-east-1", "US-WEST-2", "eu-central-1"}},
+		{"camel_hump", "gh", []string{"getHandler.go", "githubAction.yml", "graphql.go"}},
+		{"word_boundary", "mn", []string{"main_notes.txt", "my_notes.txt", "manifestNotes.json"}},
+		{"no_match", "xyz123", []string{"alpha", "beta", "gamma"}},
+		{"empty_pattern", "", []string{"alpha", "beta", "gamma"}},
+		{"non_ascii", "café", []string{"café-menu.md", "cafe-menu.md", "résumé.pdf"}},
+	}
+
+	for _, tc := range filterCases {
+		v1 := fuzzyfilter.FilterV1(tc.pattern, tc.candidates)
+		fs.AddTestWithCategory(fmt.Sprintf("select_filter_v1_%s", tc.name), "fuzzy_filter",
+			map[string]interface{}{
+				"pattern":    tc.pattern,
+				"candidates": tc.candidates,
+				"algorithm":  "v1",
+			},
+			map[string]interface{}{
+				"results": selectFilterResultFixtures(v1),
+			},
+		)
+
+		v2 := fuzzyfilter.FilterV2(tc.pattern, tc.candidates)
+		fs.AddTestWithCategory(fmt.Sprintf("select_filter_v2_%s", tc.name), "fuzzy_filter",
+			map[string]interface{}{
+				"pattern":    tc.pattern,
+				"candidates": tc.candidates,
+				"algorithm":  "v2",
+			},
+			map[string]interface{}{
+				"results": selectFilterResultFixtures(v2),
+			},
+		)
+	}
+}
+
+// themesTestdataDir locates this package's testdata/themes directory by the
+// source file's own path rather than the process's working directory, so
+// captureThemeFileTests works the same whether it's run directly or
+// through the capture_all orchestrator.
+func themesTestdataDir() string {
+	_, thisFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(thisFile), "testdata", "themes")
+}
+
+// captureThemeFileTests loads every canonical theme file under
+// testdata/themes, renders a representative select form with it, and
+// captures the rendered frame so a Rust port must reproduce the same
+// styling from the same theme file rather than a hardcoded Go constructor.
+func captureThemeFileTests(fs *capture.FixtureSet) error {
+	pattern := filepath.Join(themesTestdataDir(), "*.toml")
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("theme file capture: globbing %s: %w", pattern, err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		theme, err := themes.LoadFromFile(path)
+		if err != nil {
+			return fmt.Errorf("theme file capture: %w", err)
+		}
+
+		var value string
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[string]().
+					Title("Choose a color").
+					Description("Pick one").
+					Options(
+						huh.NewOption("Red", "red"),
+						huh.NewOption("Green", "green"),
+						huh.NewOption("Blue", "blue"),
+					).
+					Value(&value),
+			),
+		).WithTheme(theme)
+
+		form.Init()
+		view := form.View()
+
+		fs.AddTestWithCategory(fmt.Sprintf("theme_file_%s", name), "theme_file",
+			map[string]interface{}{
+				"theme_file": name,
+			},
+			map[string]interface{}{
+				"rendered_view": view,
+			},
+		)
+	}
+	return nil
+}
+
+// optionFixtures flattens huh.Option[string] values into their key/label
+// pairs for fixture input, since huh.Option itself doesn't marshal to JSON.
+func optionFixtures(opts []huh.Option[string]) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(opts))
+	for i, o := range opts {
+		out[i] = map[string]interface{}{
+			"key":   o.Value,
+			"label": o.Key,
+		}
+	}
+	return out
+}
+
+// captureSelectDelimited exercises the "key,Display label" delimited option
+// source a scripted select would read from argv, covering both the default
+// comma delimiter and a custom one.
+func captureSelectDelimited(fs *capture.FixtureSet) {
+	cases := []struct {
+		name  string
+		delim string
+		lines []string
+	}{
+		{"comma", ",", []string{"a,Option A", "b,Option B", "c,Option C"}},
+		{"pipe", "|", []string{"red|Red Shirt", "blu|Blue Shirt"}},
+		{"no_label", ",", []string{"standalone"}},
+	}
+
+	for _, tc := range cases {
+		opts := optsource.ParseDelimited(tc.lines, tc.delim)
+
+		var value string
+		sel := huh.NewSelect[string]().
+			Title("Choose an option").
+			Options(opts...).
+			Value(&value)
+		_ = sel
+
+		fs.AddTestWithCategory(fmt.Sprintf("select_delimited_%s", tc.name), "option_source",
+			map[string]interface{}{
+				"lines":     tc.lines,
+				"delimiter": tc.delim,
+			},
+			map[string]interface{}{
+				"parsed_options": optionFixtures(opts),
+				"initial_value":  value,
+				"field_type":     "select",
+			},
+		)
+	}
+}
+
+// captureSelectStdin exercises the newline-separated stdin option source,
+// where each non-empty line becomes its own key and label.
+func captureSelectStdin(fs *capture.FixtureSet) {
+	cases := []struct {
+		name  string
+		stdin string
+	}{
+		{"basic", "alpha\nbeta\ngamma\n"},
+		{"blank_lines", "alpha\n\n\nbeta\n"},
+		{"trailing_whitespace", "alpha \n beta\n"},
+	}
+
+	for _, tc := range cases {
+		opts, err := optsource.FromReader(strings.NewReader(tc.stdin))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing stdin options: %v\n", err)
+			os.Exit(1)
+		}
+
+		var value string
+		sel := huh.NewSelect[string]().
+			Title("Choose an option").
+			Options(opts...).
+			Value(&value)
+		_ = sel
+
+		fs.AddTestWithCategory(fmt.Sprintf("select_stdin_%s", tc.name), "option_source",
+			map[string]interface{}{
+				"stdin": tc.stdin,
+			},
+			map[string]interface{}{
+				"parsed_options": optionFixtures(opts),
+				"initial_value":  value,
+				"field_type":     "select",
+			},
+		)
+	}
+}
+
+// captureSelectIfOne exercises the gum-style "--select-if-one" short
+// circuit: when exactly one option is present, the form is never shown and
+// that option's key is taken as the answer outright.
+func captureSelectIfOne(fs *capture.FixtureSet) {
+	cases := []struct {
+		name  string
+		lines []string
+	}{
+		{"single_option", []string{"only,Only Choice"}},
+		{"multiple_options", []string{"a,Option A", "b,Option B"}},
+	}
+
+	for _, tc := range cases {
+		opts := optsource.ParseDelimited(tc.lines, ",")
+		picked, skipped := optsource.SkipIfOne(opts)
+
+		output := map[string]interface{}{
+			"skipped_form": skipped,
+		}
+		if skipped {
+			output["resolved_value"] = picked.Value
+		}
+
+		fs.AddTestWithCategory(fmt.Sprintf("select_if_one_%s", tc.name), "option_source",
+			map[string]interface{}{
+				"lines": tc.lines,
+			},
+			output,
+		)
+	}
 }
@@ -2,16 +2,25 @@
 package main
 
 import (
+	"charmed_conformance/internal/borderjoin"
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/capture/fuzz"
+	"charmed_conformance/internal/pooltable"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
+	fuzzCount := flag.Int("fuzz-count", 50, "Number of property-generated cases to append per input type")
+	fuzzSeed := flag.Int64("fuzz-seed", 1, "RNG seed for property-generated cases")
 	flag.Parse()
 
 	fixtures := capture.NewFixtureSet("lipgloss", "1.1.0")
@@ -40,6 +49,33 @@ func main() {
 	// Capture place tests
 	capturePlaceTests(fixtures)
 
+	// Capture style serialization/parse round-tripping
+	captureStyleSerializationTests(fixtures)
+
+	// Capture border-join / corner-intersection behavior for adjacent
+	// bordered blocks
+	captureBorderJoinTests(fixtures)
+
+	// Capture ragged-grid (pooltable) layout tests
+	captureGridTests(fixtures)
+
+	// Capture adaptive/complete color degradation across simulated
+	// terminal profiles
+	captureAdaptiveColorTests(fixtures)
+
+	// Capture tab width, reversed/dark-background, wide-rune, and
+	// trailing-whitespace text shaping behaviors
+	captureTextShapingTests(fixtures)
+
+	if err := fuzz.FuzzStyle(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := fuzz.FuzzBorder(fixtures, *outputDir, *fuzzSeed, *fuzzCount); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -489,8 +525,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    5,  // 3 + 1 + 1
-				Height:   3,  // 1 + 1 + 1
+				Width:    5, // 3 + 1 + 1
+				Height:   3, // 1 + 1 + 1
 			},
 		)
 	}
@@ -506,8 +542,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    7,  // 3 + 2 + 2
-				Height:   3,  // 1 + 1 + 1
+				Width:    7, // 3 + 2 + 2
+				Height:   3, // 1 + 1 + 1
 			},
 		)
 	}
@@ -527,8 +563,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    7,  // 1 + 2 + 4
-				Height:   5,  // 1 + 1 + 3
+				Width:    7, // 1 + 2 + 4
+				Height:   5, // 1 + 1 + 3
 			},
 		)
 	}
@@ -544,8 +580,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    5,  // 3 + 1 + 1
-				Height:   3,  // 1 + 1 + 1
+				Width:    5, // 3 + 1 + 1
+				Height:   3, // 1 + 1 + 1
 			},
 		)
 	}
@@ -565,8 +601,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    7,  // 1 + 2 + 4
-				Height:   5,  // 1 + 1 + 3
+				Width:    7, // 1 + 2 + 4
+				Height:   5, // 1 + 1 + 3
 			},
 		)
 	}
@@ -583,8 +619,8 @@ func capturePaddingMarginTests(fs *capture.FixtureSet) {
 			},
 			capture.StyleOutput{
 				Rendered: rendered,
-				Width:    6,  // 2 + 1+1 + 1+1
-				Height:   5,  // 1 + 1+1 + 1+1
+				Width:    6, // 2 + 1+1 + 1+1
+				Height:   5, // 1 + 1+1 + 1+1
 			},
 		)
 	}
@@ -998,11 +1034,11 @@ func capturePlaceTests(fs *capture.FixtureSet) {
 		result := lipgloss.Place(10, 3, lipgloss.Center, lipgloss.Center, "Hi")
 		fs.AddTestWithCategory("place_both_center", "unit",
 			map[string]interface{}{
-				"text":              "Hi",
-				"width":             10,
-				"height":            3,
-				"horizontal_pos":    "center",
-				"vertical_pos":      "center",
+				"text":           "Hi",
+				"width":          10,
+				"height":         3,
+				"horizontal_pos": "center",
+				"vertical_pos":   "center",
 			},
 			map[string]string{
 				"result": result,
@@ -1027,3 +1063,646 @@ func capturePlaceTests(fs *capture.FixtureSet) {
 		)
 	}
 }
+
+// serializationBorders maps the border-style names captureBorderTests
+// already uses in BorderInput.BorderType to the lipgloss.Border value
+// they name.
+var serializationBorders = map[string]lipgloss.Border{
+	"normal":  lipgloss.NormalBorder(),
+	"rounded": lipgloss.RoundedBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"block":   lipgloss.BlockBorder(),
+	"hidden":  lipgloss.HiddenBorder(),
+	"ascii":   lipgloss.ASCIIBorder(),
+}
+
+// serializationAligns maps align-horizontal/align-vertical names to the
+// lipgloss.Position value they name.
+var serializationAligns = map[string]lipgloss.Position{
+	"left":   lipgloss.Left,
+	"center": lipgloss.Center,
+	"right":  lipgloss.Right,
+	"top":    lipgloss.Top,
+	"bottom": lipgloss.Bottom,
+}
+
+// serializeStyle converts in to the knz/lipgloss-convert text format:
+// semicolon-separated "property: value;" pairs, one per non-default
+// attribute, in a fixed canonical order.
+func serializeStyle(in capture.StyleSerializationInput) string {
+	var parts []string
+
+	addBool := func(name string, v bool) {
+		if v {
+			parts = append(parts, fmt.Sprintf("%s: true", name))
+		}
+	}
+	addBool("bold", in.Bold)
+	addBool("italic", in.Italic)
+	addBool("underline", in.Underline)
+	addBool("strikethrough", in.Strikethrough)
+	addBool("faint", in.Faint)
+	addBool("blink", in.Blink)
+	addBool("reverse", in.Reverse)
+
+	if in.Foreground != nil {
+		parts = append(parts, fmt.Sprintf("foreground: %s", *in.Foreground))
+	}
+	if in.Background != nil {
+		parts = append(parts, fmt.Sprintf("background: %s", *in.Background))
+	}
+	if in.Width > 0 {
+		parts = append(parts, fmt.Sprintf("width: %d", in.Width))
+	}
+	if in.Height > 0 {
+		parts = append(parts, fmt.Sprintf("height: %d", in.Height))
+	}
+	if in.AlignHorizontal != "" {
+		parts = append(parts, fmt.Sprintf("align-horizontal: %s", in.AlignHorizontal))
+	}
+	if in.AlignVertical != "" {
+		parts = append(parts, fmt.Sprintf("align-vertical: %s", in.AlignVertical))
+	}
+	if len(in.Padding) == 4 {
+		parts = append(parts, fmt.Sprintf("padding: %d %d %d %d", in.Padding[0], in.Padding[1], in.Padding[2], in.Padding[3]))
+	}
+	if len(in.Margin) == 4 {
+		parts = append(parts, fmt.Sprintf("margin: %d %d %d %d", in.Margin[0], in.Margin[1], in.Margin[2], in.Margin[3]))
+	}
+	if in.BorderStyle != "" {
+		parts = append(parts, fmt.Sprintf("border-style: %s", in.BorderStyle))
+	}
+	if in.BorderForeground != nil {
+		parts = append(parts, fmt.Sprintf("border-foreground: %s", *in.BorderForeground))
+	}
+	if in.BorderBackground != nil {
+		parts = append(parts, fmt.Sprintf("border-background: %s", *in.BorderBackground))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "; ") + ";"
+}
+
+// parseFourInts parses a space-separated "top right bottom left" quad,
+// returning nil if it isn't exactly four integers.
+func parseFourInts(s string) []int {
+	fields := strings.Fields(s)
+	if len(fields) != 4 {
+		return nil
+	}
+	sides := make([]int, 4)
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil
+		}
+		sides[i] = n
+	}
+	return sides
+}
+
+// parseSerializedStyle parses text produced by serializeStyle back into a
+// lipgloss.Style.
+func parseSerializedStyle(serialized string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	for _, pair := range strings.Split(strings.TrimSuffix(serialized, ";"), ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+
+		switch key {
+		case "bold":
+			style = style.Bold(value == "true")
+		case "italic":
+			style = style.Italic(value == "true")
+		case "underline":
+			style = style.Underline(value == "true")
+		case "strikethrough":
+			style = style.Strikethrough(value == "true")
+		case "faint":
+			style = style.Faint(value == "true")
+		case "blink":
+			style = style.Blink(value == "true")
+		case "reverse":
+			style = style.Reverse(value == "true")
+		case "foreground":
+			style = style.Foreground(lipgloss.Color(value))
+		case "background":
+			style = style.Background(lipgloss.Color(value))
+		case "width":
+			if n, err := strconv.Atoi(value); err == nil {
+				style = style.Width(n)
+			}
+		case "height":
+			if n, err := strconv.Atoi(value); err == nil {
+				style = style.Height(n)
+			}
+		case "align-horizontal":
+			if pos, ok := serializationAligns[value]; ok {
+				style = style.Align(pos)
+			}
+		case "align-vertical":
+			if pos, ok := serializationAligns[value]; ok {
+				style = style.AlignVertical(pos)
+			}
+		case "padding":
+			if sides := parseFourInts(value); sides != nil {
+				style = style.Padding(sides[0], sides[1], sides[2], sides[3])
+			}
+		case "margin":
+			if sides := parseFourInts(value); sides != nil {
+				style = style.Margin(sides[0], sides[1], sides[2], sides[3])
+			}
+		case "border-style":
+			if border, ok := serializationBorders[value]; ok {
+				style = style.Border(border)
+			}
+		case "border-foreground":
+			style = style.BorderForeground(lipgloss.Color(value))
+		case "border-background":
+			style = style.BorderBackground(lipgloss.Color(value))
+		}
+	}
+	return style
+}
+
+func captureStyleSerializationTests(fs *capture.FixtureSet) {
+	hexGreen := "#0f0"
+	hexRed := "#FF0000"
+	ansiBlue := "4"
+
+	cases := []struct {
+		name  string
+		input capture.StyleSerializationInput
+	}{
+		{"style_serialization_bold_italic_underline", capture.StyleSerializationInput{
+			Text: "Styled", Bold: true, Italic: true, Underline: true,
+		}},
+		{"style_serialization_faint_blink_reverse_strikethrough", capture.StyleSerializationInput{
+			Text: "Styled", Faint: true, Blink: true, Reverse: true, Strikethrough: true,
+		}},
+		{"style_serialization_hex_foreground", capture.StyleSerializationInput{
+			Text: "Green", Bold: true, Foreground: &hexGreen,
+		}},
+		{"style_serialization_ansi_background", capture.StyleSerializationInput{
+			Text: "Blue", Background: &ansiBlue,
+		}},
+		{"style_serialization_padding_margin", capture.StyleSerializationInput{
+			Text: "Boxed", Padding: []int{1, 2, 1, 2}, Margin: []int{1, 1, 1, 1},
+		}},
+		{"style_serialization_width_height_align", capture.StyleSerializationInput{
+			Text: "Aligned", Width: 10, Height: 3,
+			AlignHorizontal: "center", AlignVertical: "center",
+		}},
+		{"style_serialization_border_rounded_colored", capture.StyleSerializationInput{
+			Text: "Bordered", BorderStyle: "rounded",
+			BorderForeground: &hexRed,
+		}},
+		{"style_serialization_full_combination", capture.StyleSerializationInput{
+			Text: "Everything", Bold: true, Italic: true,
+			Foreground: &hexGreen, Background: &ansiBlue,
+			Padding: []int{1, 2, 1, 2}, Margin: []int{1, 1, 1, 1},
+			Width: 20, Height: 5,
+			AlignHorizontal: "center", AlignVertical: "top",
+			BorderStyle:      "rounded",
+			BorderForeground: &hexRed, BorderBackground: &ansiBlue,
+		}},
+		{"style_serialization_empty_style", capture.StyleSerializationInput{
+			Text: "Plain",
+		}},
+	}
+
+	for _, c := range cases {
+		serialized := serializeStyle(c.input)
+		roundTripped := parseSerializedStyle(serialized).Render(c.input.Text)
+		fs.AddTestWithNotes(c.name,
+			c.input,
+			capture.StyleSerializationOutput{
+				Serialized:        serialized,
+				RoundTripRendered: roundTripped,
+			},
+			"Round-tripped through serializeStyle/parseSerializedStyle must render identically to the original style",
+		)
+	}
+}
+
+func captureBorderJoinTests(fs *capture.FixtureSet) {
+	boxStyle := func(borderName string, width, height int) lipgloss.Style {
+		return lipgloss.NewStyle().
+			Border(serializationBorders[borderName]).
+			Width(width).
+			Height(height)
+	}
+
+	// Test 1: Two normal-bordered boxes of equal height joined
+	// horizontally -- the shared vertical seam should collapse from
+	// "││" into a single "│", and the top/bottom corners into "┬"/"┴".
+	{
+		left := boxStyle("normal", 5, 2).Render("Left")
+		right := boxStyle("normal", 5, 2).Render("Right")
+		naive := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+		corrected := borderjoin.MergeHorizontal(naive, lipgloss.Width(left))
+		fs.AddTestWithNotes("border_join_horizontal_normal",
+			capture.BorderJoinInput{
+				Direction: "horizontal", LeftOrTopStyle: "normal", RightOrBottom: "normal",
+				LeftOrTopText: "Left", RightOrBottomText: "Right",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Equal-height normal borders joined horizontally: seam collapses to a single line with T-junctions at top and bottom",
+		)
+	}
+
+	// Test 2: Two thick-bordered boxes joined horizontally.
+	{
+		left := boxStyle("thick", 5, 2).Render("Left")
+		right := boxStyle("thick", 5, 2).Render("Right")
+		naive := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+		corrected := borderjoin.MergeHorizontal(naive, lipgloss.Width(left))
+		fs.AddTestWithNotes("border_join_horizontal_thick",
+			capture.BorderJoinInput{
+				Direction: "horizontal", LeftOrTopStyle: "thick", RightOrBottom: "thick",
+				LeftOrTopText: "Left", RightOrBottomText: "Right",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Equal-height thick borders joined horizontally use the heavy T-junction glyphs",
+		)
+	}
+
+	// Test 3: Two double-bordered boxes joined horizontally.
+	{
+		left := boxStyle("double", 5, 2).Render("Left")
+		right := boxStyle("double", 5, 2).Render("Right")
+		naive := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+		corrected := borderjoin.MergeHorizontal(naive, lipgloss.Width(left))
+		fs.AddTestWithNotes("border_join_horizontal_double",
+			capture.BorderJoinInput{
+				Direction: "horizontal", LeftOrTopStyle: "double", RightOrBottom: "double",
+				LeftOrTopText: "Left", RightOrBottomText: "Right",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Equal-height double borders joined horizontally use the double-line T-junction glyphs",
+		)
+	}
+
+	// Test 4: Mixed weight -- a thin-bordered box joined to a
+	// thick-bordered box. dominantWeight resolves the shared corners to
+	// thick (see internal/borderjoin's doc comment on that policy).
+	{
+		left := boxStyle("normal", 5, 2).Render("Thin")
+		right := boxStyle("thick", 5, 2).Render("Thick")
+		naive := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+		corrected := borderjoin.MergeHorizontal(naive, lipgloss.Width(left))
+		fs.AddTestWithNotes("border_join_horizontal_mixed_thin_meets_thick",
+			capture.BorderJoinInput{
+				Direction: "horizontal", LeftOrTopStyle: "normal", RightOrBottom: "thick",
+				LeftOrTopText: "Thin", RightOrBottomText: "Thick",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Thin box joined to a thick box: mixed-weight junctions resolve to the dominant (thicker) weight",
+		)
+	}
+
+	// Test 5: Two normal-bordered boxes joined vertically -- the shared
+	// horizontal seam collapses from a doubled border row into a single
+	// row with "├"/"┤" junctions at the edges.
+	{
+		top := boxStyle("normal", 6, 1).Render("Top")
+		bottom := boxStyle("normal", 6, 1).Render("Bottom")
+		naive := lipgloss.JoinVertical(lipgloss.Left, top, bottom)
+		corrected := borderjoin.MergeVertical(naive, lipgloss.Height(top))
+		fs.AddTestWithNotes("border_join_vertical_normal",
+			capture.BorderJoinInput{
+				Direction: "vertical", LeftOrTopStyle: "normal", RightOrBottom: "normal",
+				LeftOrTopText: "Top", RightOrBottomText: "Bottom",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Equal-width normal borders joined vertically: seam collapses to a single row with side T-junctions",
+		)
+	}
+
+	// Test 6: Two double-bordered boxes joined vertically.
+	{
+		top := boxStyle("double", 6, 1).Render("Top")
+		bottom := boxStyle("double", 6, 1).Render("Bottom")
+		naive := lipgloss.JoinVertical(lipgloss.Left, top, bottom)
+		corrected := borderjoin.MergeVertical(naive, lipgloss.Height(top))
+		fs.AddTestWithNotes("border_join_vertical_double",
+			capture.BorderJoinInput{
+				Direction: "vertical", LeftOrTopStyle: "double", RightOrBottom: "double",
+				LeftOrTopText: "Top", RightOrBottomText: "Bottom",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Equal-width double borders joined vertically use the double-line side T-junctions",
+		)
+	}
+
+	// Test 7: Mixed weight vertically -- a rounded (thin) box stacked on
+	// a thick box.
+	{
+		top := boxStyle("rounded", 6, 1).Render("Top")
+		bottom := boxStyle("thick", 6, 1).Render("Bottom")
+		naive := lipgloss.JoinVertical(lipgloss.Left, top, bottom)
+		corrected := borderjoin.MergeVertical(naive, lipgloss.Height(top))
+		fs.AddTestWithNotes("border_join_vertical_mixed_thin_meets_thick",
+			capture.BorderJoinInput{
+				Direction: "vertical", LeftOrTopStyle: "rounded", RightOrBottom: "thick",
+				LeftOrTopText: "Top", RightOrBottomText: "Bottom",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"Rounded (thin) box stacked on a thick box: mixed-weight junctions resolve to the dominant (thicker) weight",
+		)
+	}
+
+	// Test 8: A bordered box touching a padded, borderless block -- the
+	// borderless side contributes no recognizable border rune, so the
+	// seam is left untouched rather than forcing a junction.
+	{
+		left := boxStyle("normal", 5, 2).Render("Box")
+		right := lipgloss.NewStyle().Width(5).Height(2).Padding(0, 1).Render("Plain")
+		naive := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+		corrected := borderjoin.MergeHorizontal(naive, lipgloss.Width(left))
+		fs.AddTestWithNotes("border_join_horizontal_touches_unbordered_block",
+			capture.BorderJoinInput{
+				Direction: "horizontal", LeftOrTopStyle: "normal", RightOrBottom: "none",
+				LeftOrTopText: "Box", RightOrBottomText: "Plain",
+			},
+			capture.BorderJoinOutput{Naive: naive, Corrected: corrected},
+			"A bordered box touching a borderless, padded block: no junction is possible, so the seam is left exactly as lipgloss rendered it",
+		)
+	}
+}
+
+// dimensionName maps a pooltable.Dimension to the string the JSON
+// fixtures use to name it.
+func dimensionName(dim pooltable.Dimension) string {
+	if dim == pooltable.PriorityColumn {
+		return "priority_column"
+	}
+	return "priority_list"
+}
+
+func captureGridTests(fs *capture.FixtureSet) {
+	ragged := [][]string{
+		{"Hello", "World", "!"},
+		{"Salve, mondo!"},
+		{"Hola", "mundo", "", "", "!"},
+	}
+
+	// Test 1: Ragged grid, PriorityList -- every row keeps its own
+	// widths, so rows end up different total widths.
+	{
+		rendered := pooltable.Render(ragged, pooltable.PriorityList)
+		fs.AddTestWithNotes("grid_ragged_priority_list",
+			capture.GridInput{Rows: ragged, Dimension: dimensionName(pooltable.PriorityList)},
+			capture.GridOutput{Rendered: rendered},
+			"Three rows with 3, 1, and 5 cells respectively: PriorityList sizes each row independently, so no columns line up across rows",
+		)
+	}
+
+	// Test 2: The same ragged grid, PriorityColumn -- a skeleton sized
+	// to the shortest row (1 column, from "Salve, mondo!") is shared by
+	// every row; cells beyond the skeleton overflow at natural width.
+	{
+		rendered := pooltable.Render(ragged, pooltable.PriorityColumn)
+		fs.AddTestWithNotes("grid_ragged_priority_column",
+			capture.GridInput{Rows: ragged, Dimension: dimensionName(pooltable.PriorityColumn)},
+			capture.GridOutput{Rendered: rendered},
+			"Same rows as grid_ragged_priority_list: PriorityColumn aligns a one-column skeleton (the width of \"Salve, mondo!\", the shortest row) and lets every row's remaining cells overflow past it",
+		)
+	}
+
+	// Test 3: Degenerate case -- a single-cell row spanning the full
+	// width, stacked with an ordinary multi-cell row.
+	{
+		rows := [][]string{
+			{"This row has one cell spanning the whole grid"},
+			{"A", "B", "C"},
+		}
+		rendered := pooltable.Render(rows, pooltable.PriorityList)
+		fs.AddTestWithNotes("grid_single_cell_row_full_width",
+			capture.GridInput{Rows: rows, Dimension: dimensionName(pooltable.PriorityList)},
+			capture.GridOutput{Rendered: rendered},
+			"A lone one-cell row spanning the full grid width, stacked above a three-cell row",
+		)
+	}
+
+	// Test 4: Degenerate case -- empty rows (no cells at all),
+	// interleaved with ordinary rows, render as blank lines.
+	{
+		rows := [][]string{
+			{"Top", "Row"},
+			{},
+			{"Bottom", "Row"},
+		}
+		rendered := pooltable.Render(rows, pooltable.PriorityColumn)
+		fs.AddTestWithNotes("grid_empty_rows",
+			capture.GridInput{Rows: rows, Dimension: dimensionName(pooltable.PriorityColumn)},
+			capture.GridOutput{Rendered: rendered},
+			"An empty row (no cells) between two ordinary rows renders as a blank line with no border",
+		)
+	}
+
+	// Test 5: Degenerate case -- cells carrying their own ANSI styling.
+	// cellWidth must measure rendered width, ignoring escape codes, so
+	// the skeleton and overflow widths aren't thrown off by them.
+	{
+		bold := lipgloss.NewStyle().Bold(true).Render("Bold")
+		colored := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Render("Pink")
+		rows := [][]string{
+			{bold, colored},
+			{"Plain", "Text", "Row"},
+		}
+		rendered := pooltable.Render(rows, pooltable.PriorityColumn)
+		fs.AddTestWithNotes("grid_ansi_styled_cells",
+			capture.GridInput{Rows: rows, Dimension: dimensionName(pooltable.PriorityColumn)},
+			capture.GridOutput{Rendered: rendered},
+			"Cells carrying their own bold/color ANSI styling: column widths are measured by rendered width, not byte length, so the escape codes don't inflate the skeleton",
+		)
+	}
+}
+
+// adaptiveColorProfiles enumerates the termenv profiles
+// captureAdaptiveColorTests simulates, paired with the fixture-name/JSON
+// string each one is recorded under.
+var adaptiveColorProfiles = []struct {
+	name    string
+	profile termenv.Profile
+}{
+	{"ascii", termenv.Ascii},
+	{"ansi", termenv.ANSI},
+	{"ansi256", termenv.ANSI256},
+	{"truecolor", termenv.TrueColor},
+}
+
+func captureAdaptiveColorTests(fs *capture.FixtureSet) {
+	colors := []struct {
+		kind  string
+		color lipgloss.TerminalColor
+	}{
+		{"adaptive", lipgloss.AdaptiveColor{Light: "236", Dark: "248"}},
+		{"complete", lipgloss.CompleteColor{TrueColor: "#FF6AC1", ANSI256: "212", ANSI: "5"}},
+		{"complete_adaptive", lipgloss.CompleteAdaptiveColor{
+			Light: lipgloss.CompleteColor{TrueColor: "#0000FF", ANSI256: "21", ANSI: "4"},
+			Dark:  lipgloss.CompleteColor{TrueColor: "#00FF00", ANSI256: "46", ANSI: "2"},
+		}},
+	}
+
+	for _, c := range colors {
+		for _, p := range adaptiveColorProfiles {
+			for _, dark := range []bool{true, false} {
+				renderer := lipgloss.NewRenderer(io.Discard)
+				renderer.SetColorProfile(p.profile)
+				renderer.SetHasDarkBackground(dark)
+
+				rendered := renderer.NewStyle().Foreground(c.color).Render("Text")
+
+				bgName := "light"
+				if dark {
+					bgName = "dark"
+				}
+				name := fmt.Sprintf("adaptive_color_%s_%s_%s", c.kind, p.name, bgName)
+				fs.AddTestWithEnvironment(name,
+					capture.AdaptiveInput{
+						ColorKind: c.kind, Text: "Text", Profile: p.name, HasDarkBackground: dark,
+					},
+					capture.AdaptiveOutput{Rendered: rendered},
+					map[string]string{
+						"profile":             p.name,
+						"has_dark_background": strconv.FormatBool(dark),
+					},
+				)
+			}
+		}
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func captureTextShapingTests(fs *capture.FixtureSet) {
+	// Tests 1-5: Style.TabWidth(n) for n in {0, 1, 2, 4, 8}, on text
+	// containing a literal tab between two words.
+	for _, width := range []int{0, 1, 2, 4, 8} {
+		text := "A\tB"
+		rendered := lipgloss.NewStyle().TabWidth(width).Render(text)
+		fs.AddTestWithNotes(fmt.Sprintf("text_shaping_tab_width_%d", width),
+			capture.TextShapingInput{Text: text, TabWidth: intPtr(width)},
+			capture.TextShapingOutput{
+				Rendered:     rendered,
+				VisibleWidth: lipgloss.Width(rendered),
+				Height:       lipgloss.Height(rendered),
+			},
+			fmt.Sprintf("Style.TabWidth(%d) expands the tab between \"A\" and \"B\" to %d spaces", width, width),
+		)
+	}
+
+	// Tests 6-7: Reverse(true) combined with a Renderer whose
+	// HasDarkBackground is forced true/false -- reversing text swaps
+	// foreground and background, so the emitted escape sequence depends
+	// on which background the renderer believes it's drawing against.
+	for _, dark := range []bool{true, false} {
+		text := "Reversed"
+		renderer := lipgloss.NewRenderer(io.Discard)
+		renderer.SetColorProfile(termenv.TrueColor)
+		renderer.SetHasDarkBackground(dark)
+		rendered := renderer.NewStyle().Reverse(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "0", Dark: "15"}).
+			Render(text)
+
+		bgName := "light"
+		if dark {
+			bgName = "dark"
+		}
+		fs.AddTestWithNotes("text_shaping_reverse_"+bgName+"_background",
+			capture.TextShapingInput{Text: text, Reverse: true, HasDarkBackground: boolPtr(dark)},
+			capture.TextShapingOutput{
+				Rendered:     rendered,
+				VisibleWidth: lipgloss.Width(rendered),
+				Height:       lipgloss.Height(rendered),
+			},
+			fmt.Sprintf("Reverse(true) on an AdaptiveColor foreground, rendered with HasDarkBackground=%v", dark),
+		)
+	}
+
+	// Tests 8-10: East-Asian wide runes, combining marks, and a
+	// zero-width-joined family emoji, each inside a padded/bordered/
+	// aligned style, so VisibleWidth exercises the port's
+	// runewidth-equivalent rather than counting UTF-8 runes.
+	wideRuneCases := []struct {
+		name string
+		text string
+	}{
+		{"cjk_wide_runes", "日本語"},
+		{"combining_mark", "éclair"},
+		{"zwj_family_emoji", "👨‍👩‍👧"},
+	}
+	for _, c := range wideRuneCases {
+		style := lipgloss.NewStyle().
+			Padding(0, 1).
+			Border(lipgloss.NormalBorder()).
+			Width(12).
+			Align(lipgloss.Center)
+		rendered := style.Render(c.text)
+		fs.AddTestWithNotes("text_shaping_"+c.name,
+			capture.TextShapingInput{
+				Text: c.text, Width: 12, Padding: []int{0, 1},
+				BorderStyle: "normal", AlignHorizontal: "center",
+			},
+			capture.TextShapingOutput{
+				Rendered:     rendered,
+				VisibleWidth: lipgloss.Width(rendered),
+				Height:       lipgloss.Height(rendered),
+			},
+			"Centered, padded, bordered block around text whose visible column width differs from its rune count",
+		)
+	}
+
+	// Test 11: Unicode wide runes without any extra style, as a
+	// narrower baseline measurement for VisibleWidth alone.
+	{
+		text := "日本語"
+		rendered := lipgloss.NewStyle().Render(text)
+		fs.AddTestWithNotes("text_shaping_cjk_wide_runes_unstyled",
+			capture.TextShapingInput{Text: text},
+			capture.TextShapingOutput{
+				Rendered:     rendered,
+				VisibleWidth: lipgloss.Width(rendered),
+				Height:       lipgloss.Height(rendered),
+			},
+			"Unstyled CJK text: each rune occupies two visible columns",
+		)
+	}
+
+	// Test 12: Trailing whitespace preservation when Background is set
+	// -- lipgloss intentionally pads short lines out to the style's
+	// width with background-colored spaces rather than trimming them.
+	{
+		text := "Hi"
+		bg := "99"
+		rendered := lipgloss.NewStyle().Background(lipgloss.Color(bg)).Width(10).Render(text)
+		fs.AddTestWithNotes("text_shaping_trailing_whitespace_background",
+			capture.TextShapingInput{Text: text, Background: capture.Ptr(bg), Width: 10},
+			capture.TextShapingOutput{
+				Rendered:     rendered,
+				VisibleWidth: lipgloss.Width(rendered),
+				Height:       lipgloss.Height(rendered),
+			},
+			"Width(10) on \"Hi\" with a Background set: the 8 trailing columns are padded with background-colored spaces, not trimmed",
+		)
+	}
+}
@@ -4,13 +4,18 @@ package main
 import (
 	"bytes"
 	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/rotatelog"
+	"compress/gzip"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
 )
 
 func main() {
@@ -37,6 +42,21 @@ func main() {
 	// Capture caller tests
 	captureCallerTests(fixtures)
 
+	// Capture JSON/logfmt formatter tests
+	captureStructuredFormatterTests(fixtures)
+
+	// Capture colorized ANSI output tests
+	captureColorOutputTests(fixtures)
+
+	// Capture custom per-level style tests
+	captureCustomStylesTests(fixtures)
+
+	// Capture rotating/compressed file-writer tests
+	if err := captureRotationTests(fixtures); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -182,8 +202,8 @@ func captureLogFormatTests(fs *capture.FixtureSet) {
 				"args":   []interface{}{42},
 			},
 			map[string]interface{}{
-				"output":       strings.TrimSpace(output),
-				"contains_42":  strings.Contains(output, "42"),
+				"output":      strings.TrimSpace(output),
+				"contains_42": strings.Contains(output, "42"),
 			},
 		)
 	}
@@ -355,7 +375,7 @@ func captureTimestampTests(fs *capture.FixtureSet) {
 				"format":            "2006-01-02",
 			},
 			map[string]interface{}{
-				"output":       strings.TrimSpace(output),
+				"output":        strings.TrimSpace(output),
 				"contains_dash": strings.Contains(output, "-"),
 			},
 		)
@@ -460,3 +480,407 @@ func captureCallerTests(fs *capture.FixtureSet) {
 		)
 	}
 }
+
+// structuredFormatterLevels and structuredFormatterFields are the level and
+// field-type matrices captureStructuredFormatterTests crosses to exercise
+// JSON/logfmt encoding of every value shape the Rust port needs to match.
+var structuredFormatterLevels = []struct {
+	name  string
+	level log.Level
+}{
+	{"debug", log.DebugLevel},
+	{"info", log.InfoLevel},
+	{"warn", log.WarnLevel},
+	{"error", log.ErrorLevel},
+}
+
+var structuredFormatterFields = []struct {
+	name  string
+	key   string
+	value interface{}
+}{
+	{"string", "user", "alice"},
+	{"int", "count", 42},
+	{"float", "rate", 3.14},
+	{"bool", "enabled", true},
+}
+
+// logAtLevel emits message with key/value through logger at level, mirroring
+// the small set of levels charmbracelet/log exposes as named methods.
+func logAtLevel(logger *log.Logger, level log.Level, message string, keyvals ...interface{}) {
+	switch level {
+	case log.DebugLevel:
+		logger.Debug(message, keyvals...)
+	case log.WarnLevel:
+		logger.Warn(message, keyvals...)
+	case log.ErrorLevel:
+		logger.Error(message, keyvals...)
+	default:
+		logger.Info(message, keyvals...)
+	}
+}
+
+// captureStructuredFormatterTests covers log.JSONFormatter and
+// log.LogfmtFormatter across every level and structured field type, keeping
+// timestamps disabled so the captured line is byte-exact and reproducible:
+// the Rust port needs field ordering, key naming, and numeric/bool encoding
+// verbatim rather than the "contains_*" substring checks used elsewhere in
+// this file.
+func captureStructuredFormatterTests(fs *capture.FixtureSet) {
+	formatters := []struct {
+		name      string
+		formatter log.Formatter
+	}{
+		{"json", log.JSONFormatter},
+		{"logfmt", log.LogfmtFormatter},
+	}
+
+	for _, formatter := range formatters {
+		for _, lvl := range structuredFormatterLevels {
+			for _, field := range structuredFormatterFields {
+				var buf bytes.Buffer
+				logger := log.New(&buf)
+				logger.SetFormatter(formatter.formatter)
+				logger.SetReportTimestamp(false)
+				logger.SetReportCaller(false)
+				logger.SetLevel(log.DebugLevel)
+				logAtLevel(logger, lvl.level, "event", field.key, field.value)
+
+				output := strings.TrimRight(buf.String(), "\n")
+				fs.AddTestWithCategory(
+					fmt.Sprintf("formatter_%s_%s_%s", formatter.name, lvl.name, field.name),
+					"formatter",
+					map[string]interface{}{
+						"formatter":   formatter.name,
+						"level":       lvl.name,
+						"message":     "event",
+						"field_key":   field.key,
+						"field_value": field.value,
+					},
+					map[string]interface{}{
+						"output": output,
+					},
+				)
+			}
+		}
+	}
+
+	// Timestamp key naming: the formatter's own key for the timestamp field
+	// ("time" for both JSON and logfmt) rather than its volatile wall-clock
+	// value, so this fixture stays reproducible across captures.
+	for _, formatter := range formatters {
+		var buf bytes.Buffer
+		logger := log.New(&buf)
+		logger.SetFormatter(formatter.formatter)
+		logger.SetReportTimestamp(true)
+		logger.SetTimeFormat(time.RFC3339)
+		logger.Info("timestamped event")
+
+		output := strings.TrimRight(buf.String(), "\n")
+		fs.AddTestWithCategory(
+			fmt.Sprintf("formatter_%s_timestamp_key", formatter.name),
+			"formatter",
+			map[string]interface{}{
+				"formatter":         formatter.name,
+				"timestamp_enabled": true,
+			},
+			map[string]interface{}{
+				"timestamp_key": firstFieldKey(output, formatter.name),
+			},
+		)
+	}
+}
+
+// firstFieldKey extracts the key name of the leading field in a logfmt or
+// JSON log line (where charmbracelet/log always places the timestamp, when
+// enabled) without depending on its volatile value.
+func firstFieldKey(output, formatterName string) string {
+	if formatterName == "json" {
+		trimmed := strings.TrimPrefix(output, `{"`)
+		if idx := strings.Index(trimmed, `"`); idx >= 0 {
+			return trimmed[:idx]
+		}
+		return ""
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.SplitN(fields[0], "=", 2)[0]
+}
+
+// captureColorOutputTests forces TrueColor output via SetColorProfile --
+// charmbracelet/log otherwise disables styling when its writer isn't a
+// terminal, which a bytes.Buffer never is -- and captures the raw SGR escape
+// sequences around level prefixes, keys, values, and caller info so the Rust
+// port's ANSI generation can be diffed byte-for-byte.
+func captureColorOutputTests(fs *capture.FixtureSet) {
+	for _, lvl := range structuredFormatterLevels {
+		var buf bytes.Buffer
+		logger := log.New(&buf)
+		logger.SetColorProfile(termenv.TrueColor)
+		logger.SetReportTimestamp(false)
+		logger.SetReportCaller(true)
+		logger.SetLevel(log.DebugLevel)
+		logAtLevel(logger, lvl.level, "colored event", "key", "value")
+
+		output := buf.String()
+		fs.AddTestWithCategory(fmt.Sprintf("color_%s", lvl.name), "color",
+			map[string]interface{}{
+				"level":   lvl.name,
+				"message": "colored event",
+				"fields":  map[string]interface{}{"key": "value"},
+			},
+			map[string]interface{}{
+				"output":          output,
+				"contains_escape": strings.Contains(output, "\x1b["),
+			},
+		)
+	}
+}
+
+// captureCustomStylesTests overrides log.DefaultStyles's per-level style
+// with custom lipgloss.Style values via SetStyles, locking down how the log
+// and lipgloss subsystems interact when rendering a colorized line.
+func captureCustomStylesTests(fs *capture.FixtureSet) {
+	styles := log.DefaultStyles()
+	styles.Levels[log.InfoLevel] = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("205")).
+		Bold(true).
+		SetString("CUSTOM")
+	styles.Levels[log.ErrorLevel] = lipgloss.NewStyle().
+		Foreground(lipgloss.Color("196")).
+		Reverse(true).
+		SetString("BOOM")
+	styles.Keys["key"] = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	styles.Values["key"] = lipgloss.NewStyle().Italic(true)
+
+	for _, lvl := range []struct {
+		name  string
+		level log.Level
+	}{
+		{"info", log.InfoLevel},
+		{"error", log.ErrorLevel},
+	} {
+		var buf bytes.Buffer
+		logger := log.New(&buf)
+		logger.SetColorProfile(termenv.TrueColor)
+		logger.SetStyles(styles)
+		logger.SetReportTimestamp(false)
+		logger.SetLevel(log.DebugLevel)
+		logAtLevel(logger, lvl.level, "styled event", "key", "value")
+
+		output := buf.String()
+		fs.AddTestWithCategory(fmt.Sprintf("custom_styles_%s", lvl.name), "color",
+			map[string]interface{}{
+				"level":   lvl.name,
+				"message": "styled event",
+				"fields":  map[string]interface{}{"key": "value"},
+			},
+			map[string]interface{}{
+				"output": output,
+			},
+		)
+	}
+}
+
+// frozenClock advances a fixed starting time by step on every call, giving
+// captureRotationTests a deterministic but still-advancing clock to drive
+// rotatelog.Rotator's time-triggered policy without depending on wall time.
+func frozenClock(start time.Time, step time.Duration) rotatelog.Clock {
+	current := start
+	return func() time.Time {
+		now := current
+		current = current.Add(step)
+		return now
+	}
+}
+
+// gzipMagic reads the first two bytes of a gzip member, the magic bytes
+// (0x1f, 0x8b) a Rust port needs to recognize a rotated file's framing.
+func gzipMagic(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 {
+		return data, nil
+	}
+	return data[:2], nil
+}
+
+// readGzip decompresses a rotated *.gz file, returning its original
+// contents for comparison against the RotationEvent.ActiveBytes captured at
+// rotation time.
+func readGzip(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gr.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(gr); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// captureRotationTests drives a logger through an in-tree rotatelog.Rotator
+// under a frozen clock, forcing both a size-triggered and a time-triggered
+// rotation, and captures the rotated filename pattern, gzip framing,
+// retention after pruning, and the exact active-file bytes at each rotation
+// boundary -- a precise, replayable specification for a rotating gzipped
+// file sink.
+func captureRotationTests(fs *capture.FixtureSet) error {
+	if err := captureSizeTriggeredRotation(fs); err != nil {
+		return err
+	}
+	return captureTimeTriggeredRotation(fs)
+}
+
+func captureSizeTriggeredRotation(fs *capture.FixtureSet) error {
+	dir, err := os.MkdirTemp("", "log_rotation_size")
+	if err != nil {
+		return fmt.Errorf("rotation capture: creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rotator := &rotatelog.Rotator{
+		Dir:          dir,
+		BaseName:     "app.log",
+		MaxSizeBytes: 64,
+		MaxBackups:   2,
+		Now:          frozenClock(start, time.Second),
+	}
+	defer rotator.Close()
+
+	logger := log.New(rotator)
+	logger.SetReportTimestamp(false)
+	logger.SetLevel(log.DebugLevel)
+
+	entries := []struct {
+		level   log.Level
+		message string
+	}{
+		{log.InfoLevel, "server started on port 8080"},
+		{log.WarnLevel, "connection pool nearing capacity"},
+		{log.ErrorLevel, "failed to reach upstream service"},
+		{log.InfoLevel, "retrying upstream connection"},
+		{log.InfoLevel, "upstream connection restored"},
+	}
+	for _, e := range entries {
+		logAtLevel(logger, e.level, e.message)
+	}
+
+	events := rotator.Rotations()
+	eventFixtures := make([]map[string]interface{}, 0, len(events))
+	for _, ev := range events {
+		rotatedPath := filepath.Join(dir, ev.RotatedName)
+		magic, err := gzipMagic(rotatedPath)
+		if err != nil {
+			return fmt.Errorf("rotation capture: reading gzip magic: %w", err)
+		}
+		decompressed, err := readGzip(rotatedPath)
+		if err != nil {
+			return fmt.Errorf("rotation capture: decompressing rotated file: %w", err)
+		}
+		eventFixtures = append(eventFixtures, map[string]interface{}{
+			"trigger":              ev.Trigger,
+			"rotated_name":         ev.RotatedName,
+			"active_bytes":         string(ev.ActiveBytes),
+			"gzip_magic_bytes":     magic,
+			"decompressed_matches": decompressed == string(ev.ActiveBytes),
+			"backups_after":        ev.BackupsAfter,
+			"retention_count":      len(ev.BackupsAfter),
+		})
+	}
+
+	fs.AddTestWithCategory("rotation_size_triggered", "rotation",
+		map[string]interface{}{
+			"max_size_bytes": rotator.MaxSizeBytes,
+			"max_backups":    rotator.MaxBackups,
+			"base_name":      "app.log",
+			"entries": func() []string {
+				msgs := make([]string, len(entries))
+				for i, e := range entries {
+					msgs[i] = e.message
+				}
+				return msgs
+			}(),
+		},
+		map[string]interface{}{
+			"rotation_count":      len(events),
+			"rotations":           eventFixtures,
+			"filename_pattern":    "<stem>-20060102T150405.<ext>.gz",
+			"gzip_magic_expected": []byte{0x1f, 0x8b},
+		},
+	)
+	return nil
+}
+
+func captureTimeTriggeredRotation(fs *capture.FixtureSet) error {
+	dir, err := os.MkdirTemp("", "log_rotation_time")
+	if err != nil {
+		return fmt.Errorf("rotation capture: creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	start := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	rotator := &rotatelog.Rotator{
+		Dir:            dir,
+		BaseName:       "app.log",
+		RotateInterval: 5 * time.Second,
+		MaxBackups:     1,
+		Now:            frozenClock(start, 2*time.Second),
+	}
+	defer rotator.Close()
+
+	logger := log.New(rotator)
+	logger.SetReportTimestamp(false)
+	logger.SetLevel(log.DebugLevel)
+
+	for i := 0; i < 6; i++ {
+		logger.Info(fmt.Sprintf("tick %d", i))
+	}
+
+	events := rotator.Rotations()
+	eventFixtures := make([]map[string]interface{}, 0, len(events))
+	for _, ev := range events {
+		rotatedPath := filepath.Join(dir, ev.RotatedName)
+		magic, err := gzipMagic(rotatedPath)
+		if err != nil {
+			return fmt.Errorf("rotation capture: reading gzip magic: %w", err)
+		}
+		eventFixtures = append(eventFixtures, map[string]interface{}{
+			"trigger":          ev.Trigger,
+			"rotated_name":     ev.RotatedName,
+			"active_bytes":     string(ev.ActiveBytes),
+			"gzip_magic_bytes": magic,
+			"backups_after":    ev.BackupsAfter,
+			"retention_count":  len(ev.BackupsAfter),
+		})
+	}
+
+	fs.AddTestWithCategory("rotation_time_triggered", "rotation",
+		map[string]interface{}{
+			"rotate_interval_seconds": rotator.RotateInterval.Seconds(),
+			"max_backups":             rotator.MaxBackups,
+			"base_name":               "app.log",
+			"tick_count":              6,
+		},
+		map[string]interface{}{
+			"rotation_count":   len(events),
+			"rotations":        eventFixtures,
+			"filename_pattern": "<stem>-20060102T150405.<ext>.gz",
+		},
+	)
+	return nil
+}
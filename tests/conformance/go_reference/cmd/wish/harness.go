@@ -0,0 +1,341 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	"github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/elapsed"
+	"github.com/charmbracelet/wish/logging"
+	recovermw "github.com/charmbracelet/wish/recover"
+	gossh "golang.org/x/crypto/ssh"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// testPassword is the password accepted by every harness server configured
+// for password auth -- there's no real credential to protect here, only
+// wire-level behavior to capture.
+const testPassword = "conformance-test-password"
+
+// traceEvent is one middleware entry or exit, timestamped relative to when
+// its session's harness started so a Rust port can assert ordering without
+// depending on wall-clock time.
+type traceEvent struct {
+	Middleware string `json:"middleware"`
+	Phase      string `json:"phase"`
+	AtMillis   int64  `json:"at_millis"`
+}
+
+// sessionTrace collects traceEvents across a single session's middleware
+// chain. A session only ever runs on one goroutine, but the mutex keeps
+// this safe if a future middleware spawns one of its own.
+type sessionTrace struct {
+	mu     sync.Mutex
+	start  time.Time
+	events []traceEvent
+}
+
+func newSessionTrace() *sessionTrace {
+	return &sessionTrace{start: time.Now()}
+}
+
+func (t *sessionTrace) record(middleware, phase string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, traceEvent{
+		Middleware: middleware,
+		Phase:      phase,
+		AtMillis:   time.Since(t.start).Milliseconds(),
+	})
+}
+
+func (t *sessionTrace) snapshot() []traceEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]traceEvent(nil), t.events...)
+}
+
+// traced wraps mw so every invocation of the handler it produces is bracketed
+// by an "enter"/"exit" event in trace, recording the real order wish invokes
+// its configured middleware in rather than describing it in prose.
+func traced(trace *sessionTrace, name string, mw wish.Middleware) wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		wrapped := mw(next)
+		return func(s ssh.Session) {
+			trace.record(name, "enter")
+			wrapped(s)
+			trace.record(name, "exit")
+		}
+	}
+}
+
+// quitModel is the smallest possible bubbletea program: it quits on its
+// first Init, just enough for bubbletea.Middleware to have something real
+// to run and tear down during a captured session.
+type quitModel struct{}
+
+func (quitModel) Init() tea.Cmd                       { return tea.Quit }
+func (quitModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return quitModel{}, tea.Quit }
+func (quitModel) View() string                        { return "" }
+
+func quitModelProgram(ssh.Session) (tea.Model, []tea.ProgramOption) {
+	return quitModel{}, nil
+}
+
+// middlewareByName returns the real wish middleware for one of the names in
+// captureMiddlewareTests's list, wrapped with traced so its entry/exit shows
+// up in trace. Names outside this harness's scope (git/scp/sftp/pty/session
+// handling, which aren't standalone wish/* middleware packages) pass through
+// untraced and unchanged.
+func middlewareByName(trace *sessionTrace, name string) wish.Middleware {
+	switch name {
+	case "activeterm":
+		return traced(trace, name, activeterm.Middleware())
+	case "elapsed":
+		return traced(trace, name, elapsed.Middleware())
+	case "logging":
+		return traced(trace, name, logging.Middleware())
+	case "recovery":
+		return traced(trace, name, recovermw.Middleware())
+	case "bubbletea":
+		return traced(trace, name, bubbletea.Middleware(quitModelProgram))
+	default:
+		return func(next ssh.Handler) ssh.Handler { return next }
+	}
+}
+
+// baseHandler is the innermost handler every middleware chain eventually
+// reaches: it acknowledges a PTY if one was requested and exits 0.
+func baseHandler(s ssh.Session) {
+	if _, _, isPty := s.Pty(); isPty {
+		io.WriteString(s, "conformance harness session\n")
+	}
+	s.Exit(0)
+}
+
+// hostKey generates a fresh ed25519 host key for one ephemeral server,
+// returning its PEM encoding (for wish.WithHostKeyPEM) and SHA-256
+// fingerprint (for comparing against what the client actually observed).
+func hostKey() (pemBytes []byte, fingerprint string, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("wish harness: generating host key: %w", err)
+	}
+	block, err := gossh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("wish harness: marshaling host key: %w", err)
+	}
+	signer, err := gossh.NewSignerFromSigner(priv)
+	if err != nil {
+		return nil, "", fmt.Errorf("wish harness: building host signer: %w", err)
+	}
+	_ = pub
+	return pem.EncodeToMemory(block), gossh.FingerprintSHA256(signer.PublicKey()), nil
+}
+
+// authOption returns the ssh.Option configuring the server to accept mode,
+// plus a matching golang.org/x/crypto/ssh AuthMethod a client should offer.
+// "none" configures no auth handler at all, matching how an unconfigured
+// wish/ssh server accepts any client without requiring authentication.
+// wish's own With* helpers are built against charmbracelet/ssh and return
+// ssh.Option, not a distinct wish.Option type.
+func authOption(mode string, clientSigner gossh.Signer) (ssh.Option, gossh.AuthMethod) {
+	switch mode {
+	case "password":
+		return wish.WithPasswordAuth(func(ctx ssh.Context, password string) bool {
+				return password == testPassword
+			}),
+			gossh.Password(testPassword)
+	case "publickey":
+		return wish.WithPublicKeyAuth(func(ctx ssh.Context, key ssh.PublicKey) bool {
+				return true
+			}),
+			gossh.PublicKeys(clientSigner)
+	case "keyboard-interactive":
+		return wish.WithKeyboardInteractiveAuth(func(ctx ssh.Context, _ gossh.KeyboardInteractiveChallenge) bool {
+				return true
+			}),
+			gossh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				return make([]string, len(questions)), nil
+			})
+	default: // "none"
+		return func(*ssh.Server) error { return nil }, nil
+	}
+}
+
+// ephemeralListener binds a random free localhost port, the way a real
+// capture run picks one instead of risking a collision on a fixed port.
+func ephemeralListener() (net.Listener, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("wish harness: binding ephemeral listener: %w", err)
+	}
+	return ln, nil
+}
+
+// connectionInfo is what a client observes negotiating and authenticating
+// with one harness server.
+type connectionInfo struct {
+	ServerVersion      string
+	ClientVersion      string
+	HostKeyFingerprint string
+	Banner             string
+}
+
+// sessionArtifacts is what a client observes exercising one session against
+// a harness server: PTY/window-change requests and the final exit status.
+type sessionArtifacts struct {
+	PtyRequested      bool
+	PtyError          string
+	WindowChangeSent  bool
+	WindowChangeError string
+	ExitStatus        int
+	WaitError         string
+}
+
+// runSession connects to addr with mode's auth method, requests a PTY,
+// sends a window-change, starts a shell, and waits for it to exit -- the
+// real SSH wire-level round trip the Rust port needs byte-level truth for.
+func runSession(addr, mode string, keyExchanges, ciphers, macs []string, clientSigner gossh.Signer) (connectionInfo, sessionArtifacts, error) {
+	var info connectionInfo
+
+	_, auth := authOption(mode, clientSigner)
+	cfg := &gossh.ClientConfig{
+		User:    "conformance",
+		Timeout: 5 * time.Second,
+		Config: gossh.Config{
+			KeyExchanges: keyExchanges,
+			Ciphers:      ciphers,
+			MACs:         macs,
+		},
+		HostKeyCallback: func(_ string, _ net.Addr, key gossh.PublicKey) error {
+			info.HostKeyFingerprint = gossh.FingerprintSHA256(key)
+			return nil
+		},
+		BannerCallback: func(message string) error {
+			info.Banner = message
+			return nil
+		},
+	}
+	if auth != nil {
+		cfg.Auth = []gossh.AuthMethod{auth}
+	}
+
+	client, err := gossh.Dial("tcp", addr, cfg)
+	if err != nil {
+		return info, sessionArtifacts{}, fmt.Errorf("wish harness: dialing: %w", err)
+	}
+	defer client.Close()
+
+	info.ServerVersion = string(client.ServerVersion())
+	info.ClientVersion = string(client.ClientVersion())
+
+	artifacts, err := exerciseSession(client)
+	return info, artifacts, err
+}
+
+func exerciseSession(client *gossh.Client) (sessionArtifacts, error) {
+	var artifacts sessionArtifacts
+
+	session, err := client.NewSession()
+	if err != nil {
+		return artifacts, fmt.Errorf("wish harness: opening session: %w", err)
+	}
+	defer session.Close()
+
+	if err := session.RequestPty("xterm-256color", 24, 80, gossh.TerminalModes{}); err != nil {
+		artifacts.PtyError = err.Error()
+	} else {
+		artifacts.PtyRequested = true
+	}
+
+	if err := session.WindowChange(30, 100); err != nil {
+		artifacts.WindowChangeError = err.Error()
+	} else {
+		artifacts.WindowChangeSent = true
+	}
+
+	if err := session.Shell(); err != nil {
+		return artifacts, fmt.Errorf("wish harness: starting shell: %w", err)
+	}
+
+	switch err := session.Wait().(type) {
+	case nil:
+		artifacts.ExitStatus = 0
+	case *gossh.ExitError:
+		artifacts.ExitStatus = err.ExitStatus()
+	default:
+		artifacts.WaitError = err.Error()
+	}
+	return artifacts, nil
+}
+
+// withHarnessServer spins up an ephemeral wish server using middleware and
+// mode's auth handler (accepting clientSigner for publickey mode), runs fn
+// against its address, and tears the server down afterward.
+func withHarnessServer(mode string, clientSigner gossh.Signer, middleware []wish.Middleware, fn func(addr string) error) error {
+	ln, err := ephemeralListener()
+	if err != nil {
+		return err
+	}
+	addr := ln.Addr().String()
+
+	pemBytes, _, err := hostKey()
+	if err != nil {
+		ln.Close()
+		return err
+	}
+
+	modeOption, _ := authOption(mode, clientSigner)
+	opts := []ssh.Option{
+		wish.WithHostKeyPEM(pemBytes),
+		wish.WithMiddleware(middleware...),
+		modeOption,
+	}
+
+	srv, err := wish.NewServer(opts...)
+	if err != nil {
+		ln.Close()
+		return fmt.Errorf("wish harness: building server: %w", err)
+	}
+	srv.Handler = baseHandler
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+	defer srv.Close()
+
+	if err := fn(addr); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-serveErr:
+		if err != nil && err != ssh.ErrServerClosed {
+			return fmt.Errorf("wish harness: server error: %w", err)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+	return nil
+}
+
+func newClientSigner() (gossh.Signer, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("wish harness: generating client key: %w", err)
+	}
+	signer, err := gossh.NewSignerFromSigner(priv)
+	if err != nil {
+		return nil, fmt.Errorf("wish harness: building client signer: %w", err)
+	}
+	return signer, nil
+}
@@ -12,6 +12,11 @@ import (
 	"github.com/charmbracelet/wish"
 )
 
+// authModes lists the auth modes captureSessionAuthTests and
+// captureAlgorithmTests connect with -- matching the set of
+// wish.With*Auth options the server option tests above describe.
+var authModes = []string{"none", "password", "publickey", "keyboard-interactive"}
+
 func main() {
 	outputDir := flag.String("output", "output", "Output directory for fixtures")
 	flag.Parse()
@@ -30,6 +35,21 @@ func main() {
 	// Capture error tests
 	captureErrorTests(fixtures)
 
+	// Capture real SSH wire-level session behavior against an ephemeral
+	// server: auth modes, middleware chain tracing, and algorithm negotiation.
+	if err := captureSessionAuthTests(fixtures); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := captureMiddlewareTraceTests(fixtures); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := captureAlgorithmTests(fixtures); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if err := fixtures.WriteToFile(*outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -82,7 +102,7 @@ func captureServerOptionTests(fs *capture.FixtureSet) {
 	{
 		fs.AddTestWithCategory("server_with_authorized_keys", "unit",
 			map[string]interface{}{
-				"option":            "WithAuthorizedKeys",
+				"option":               "WithAuthorizedKeys",
 				"authorized_keys_path": "/path/to/authorized_keys",
 			},
 			map[string]interface{}{
@@ -257,9 +277,9 @@ func captureMiddlewareTests(fs *capture.FixtureSet) {
 	{
 		fs.AddTestWithCategory("middleware_chain", "unit",
 			map[string]interface{}{
-				"description":       "Middleware chaining behavior",
-				"middleware_count":  3,
-				"middleware_names":  []string{"logging", "auth", "handler"},
+				"description":      "Middleware chaining behavior",
+				"middleware_count": 3,
+				"middleware_names": []string{"logging", "auth", "handler"},
 			},
 			map[string]interface{}{
 				"execution_order": "outer_to_inner",
@@ -316,24 +336,202 @@ func captureErrorTests(fs *capture.FixtureSet) {
 				"function": "wish.Fatal",
 			},
 			map[string]interface{}{
-				"behavior":   "prints_error_and_exits",
-				"exit_code":  1,
-				"note":       "Fatal prints to stderr and calls os.Exit(1)",
+				"behavior":  "prints_error_and_exits",
+				"exit_code": 1,
+				"note":      "Fatal prints to stderr and calls os.Exit(1)",
 			},
 		)
 	}
+}
 
-	// Test error interface
-	{
-		err := wish.ErrExitStatusNotSet
-		fs.AddTestWithCategory("error_exit_status_not_set", "unit",
+// defaultTraceMiddleware is the middleware chain every auth-mode and
+// algorithm-negotiation test runs under: enough real middleware to exercise
+// the server's request pipeline without the combinatorics of varying it
+// alongside auth mode and algorithm selection too.
+var defaultTraceMiddleware = []string{"logging", "recovery"}
+
+// captureSessionAuthTests spins up one ephemeral wish server per auth mode
+// in authModes, connects a real golang.org/x/crypto/ssh client using that
+// mode, and records the exact version banner, host key fingerprint, and
+// session artifacts (PTY, window-change, exit status) it observed.
+func captureSessionAuthTests(fs *capture.FixtureSet) error {
+	for _, mode := range authModes {
+		trace := newSessionTrace()
+		middleware := make([]wish.Middleware, len(defaultTraceMiddleware))
+		for i, name := range defaultTraceMiddleware {
+			middleware[i] = middlewareByName(trace, name)
+		}
+
+		clientSigner, err := newClientSigner()
+		if err != nil {
+			return err
+		}
+
+		var info connectionInfo
+		var artifacts sessionArtifacts
+		var sessionErr error
+		err = withHarnessServer(mode, clientSigner, middleware, func(addr string) error {
+			info, artifacts, sessionErr = runSession(addr, mode, nil, nil, nil, clientSigner)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("wish capture: auth mode %q: %w", mode, err)
+		}
+
+		output := map[string]interface{}{
+			"connected":            sessionErr == nil,
+			"server_version":       info.ServerVersion,
+			"client_version":       info.ClientVersion,
+			"host_key_fingerprint": info.HostKeyFingerprint,
+			"pty_requested":        artifacts.PtyRequested,
+			"window_change_sent":   artifacts.WindowChangeSent,
+			"exit_status":          artifacts.ExitStatus,
+			"middleware_trace":     trace.snapshot(),
+		}
+		if sessionErr != nil {
+			output["error"] = sessionErr.Error()
+		}
+
+		fs.AddTestWithCategory(fmt.Sprintf("session_auth_%s", sanitizeName(mode)), "integration",
+			map[string]interface{}{
+				"auth_mode":  mode,
+				"middleware": defaultTraceMiddleware,
+			},
+			output,
+		)
+	}
+	return nil
+}
+
+// traceMiddlewareNames lists every standalone wish/* middleware package
+// captureMiddlewareTests' list maps onto -- git/scp/sftp/pty/session handling
+// aren't separate wish/* middleware and so aren't exercised here.
+var traceMiddlewareNames = []string{"activeterm", "elapsed", "logging", "recovery", "bubbletea"}
+
+// captureMiddlewareTraceTests exercises each middleware in
+// traceMiddlewareNames on its own ephemeral server, then all of them
+// together in one chain, recording the real ordered entry/exit trace a
+// synthetic wrapper observes around each middleware's handler.
+func captureMiddlewareTraceTests(fs *capture.FixtureSet) error {
+	runOne := func(name string, names []string) error {
+		trace := newSessionTrace()
+		middleware := make([]wish.Middleware, len(names))
+		for i, n := range names {
+			middleware[i] = middlewareByName(trace, n)
+		}
+
+		clientSigner, err := newClientSigner()
+		if err != nil {
+			return err
+		}
+
+		var sessionErr error
+		err = withHarnessServer("none", clientSigner, middleware, func(addr string) error {
+			_, _, sessionErr = runSession(addr, "none", nil, nil, nil, clientSigner)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("wish capture: middleware trace %q: %w", name, err)
+		}
+
+		output := map[string]interface{}{
+			"connected":        sessionErr == nil,
+			"middleware_trace": trace.snapshot(),
+		}
+		if sessionErr != nil {
+			output["error"] = sessionErr.Error()
+		}
+
+		fs.AddTestWithCategory(fmt.Sprintf("middleware_trace_%s", sanitizeName(name)), "integration",
 			map[string]interface{}{
-				"error_name": "ErrExitStatusNotSet",
+				"middleware": names,
 			},
+			output,
+		)
+		return nil
+	}
+
+	for _, name := range traceMiddlewareNames {
+		if err := runOne(name, []string{name}); err != nil {
+			return err
+		}
+	}
+	return runOne("all_middleware_chain", traceMiddlewareNames)
+}
+
+// algorithmCombos pins a single candidate kex/cipher per test case so the
+// negotiated algorithm is known by construction: golang.org/x/crypto/ssh
+// doesn't expose the negotiated suite after the fact, but if only one
+// candidate is offered and the handshake succeeds, that candidate is what
+// was used. Compression is omitted because golang.org/x/crypto/ssh never
+// negotiates anything but "none".
+var algorithmCombos = []struct {
+	name   string
+	kex    string
+	cipher string
+}{
+	{"curve25519_aes128gcm", "curve25519-sha256", "aes128-gcm@openssh.com"},
+	{"ecdh_p256_chacha20poly1305", "ecdh-sha2-nistp256", "chacha20-poly1305@openssh.com"},
+	{"dh_group14_aes256ctr", "diffie-hellman-group14-sha256", "aes256-ctr"},
+}
+
+// captureAlgorithmTests connects with each combination in algorithmCombos
+// restricted to a single kex/cipher candidate, recording the negotiated
+// values (known by construction) alongside the real connection outcome.
+func captureAlgorithmTests(fs *capture.FixtureSet) error {
+	for _, combo := range algorithmCombos {
+		trace := newSessionTrace()
+		middleware := []wish.Middleware{middlewareByName(trace, "logging")}
+
+		clientSigner, err := newClientSigner()
+		if err != nil {
+			return err
+		}
+
+		var info connectionInfo
+		var sessionErr error
+		err = withHarnessServer("none", clientSigner, middleware, func(addr string) error {
+			info, _, sessionErr = runSession(addr, "none", []string{combo.kex}, []string{combo.cipher}, nil, clientSigner)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("wish capture: algorithm combo %q: %w", combo.name, err)
+		}
+
+		output := map[string]interface{}{
+			"connected":            sessionErr == nil,
+			"kex":                  combo.kex,
+			"cipher":               combo.cipher,
+			"compression":          "none",
+			"server_version":       info.ServerVersion,
+			"client_version":       info.ClientVersion,
+			"host_key_fingerprint": info.HostKeyFingerprint,
+		}
+		if sessionErr != nil {
+			output["error"] = sessionErr.Error()
+		}
+
+		fs.AddTestWithCategory(fmt.Sprintf("algorithm_%s", combo.name), "integration",
 			map[string]interface{}{
-				"message":     err.Error(),
-				"is_sentinel": true,
+				"kex":    combo.kex,
+				"cipher": combo.cipher,
 			},
+			output,
 		)
 	}
+	return nil
+}
+
+// sanitizeName maps a hyphenated auth mode name (e.g. "keyboard-interactive")
+// to the underscore-separated form this file's other fixture names use.
+func sanitizeName(name string) string {
+	out := make([]byte, len(name))
+	for i := 0; i < len(name); i++ {
+		if name[i] == '-' {
+			out[i] = '_'
+		} else {
+			out[i] = name[i]
+		}
+	}
+	return string(out)
 }
@@ -0,0 +1,248 @@
+// Package benchreport turns this repo's `go test -bench` output into
+// Prometheus/OpenMetrics text, so Go and a prospective Rust conformance
+// harness can be tracked on the same dashboards and compared run over run.
+// The `bench` package's BenchmarkListView100, BenchmarkTableView1000,
+// BenchmarkViewportRender10000, BenchmarkMessages1000, etc. already report
+// ns/op, B/op, and allocs/op via `go test -bench -benchmem`; this package
+// parses that stock text rather than calling testing.Benchmark directly,
+// since the benchmark functions themselves live in _test.go files and
+// aren't callable from ordinary (non-test) packages.
+package benchreport
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RawResult is one benchmark line as `go test -bench -benchmem` reports
+// it, before component/op/size labels are attached.
+type RawResult struct {
+	Name        string
+	NsPerOp     float64
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+var benchLineRE = regexp.MustCompile(
+	`^(Benchmark\S+?)(?:-\d+)?\s+(\d+)\s+([\d.]+)\s+ns/op(?:\s+([\d.]+)\s+B/op)?(?:\s+(\d+)\s+allocs/op)?`,
+)
+
+// ParseGoTestBenchOutput reads the text `go test -bench=. -benchmem`
+// writes to stdout and extracts one RawResult per benchmark line,
+// ignoring PASS/ok/compile-log lines it doesn't recognize.
+func ParseGoTestBenchOutput(r io.Reader) ([]RawResult, error) {
+	var results []RawResult
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := benchLineRE.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		nsPerOp, err := strconv.ParseFloat(m[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("benchreport: parsing ns/op for %s: %w", m[1], err)
+		}
+		var bytesPerOp int64
+		if m[4] != "" {
+			f, err := strconv.ParseFloat(m[4], 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchreport: parsing B/op for %s: %w", m[1], err)
+			}
+			bytesPerOp = int64(f)
+		}
+		var allocsPerOp int64
+		if m[5] != "" {
+			allocsPerOp, err = strconv.ParseInt(m[5], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchreport: parsing allocs/op for %s: %w", m[1], err)
+			}
+		}
+		results = append(results, RawResult{
+			Name:        m[1],
+			NsPerOp:     nsPerOp,
+			AllocsPerOp: allocsPerOp,
+			BytesPerOp:  bytesPerOp,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("benchreport: scanning bench output: %w", err)
+	}
+	return results, nil
+}
+
+// RunBenchmarks shells out to `go test -bench=<pattern> -run=^$ -benchmem`
+// in pkgDir and parses the result, so callers don't have to invoke `go`
+// and ParseGoTestBenchOutput separately.
+func RunBenchmarks(pkgDir, pattern string) ([]RawResult, error) {
+	if pattern == "" {
+		pattern = "."
+	}
+	cmd := exec.Command("go", "test", "-run=^$", "-bench="+pattern, "-benchmem", "./...")
+	cmd.Dir = pkgDir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("benchreport: running go test -bench: %w\n%s", err, out.String())
+	}
+	return ParseGoTestBenchOutput(&out)
+}
+
+// labelOverride is a hand-curated component/op/size triple for the
+// benchmark names the conformance suite specifically tracks for
+// cross-language drift; everything else falls back to a generic split.
+type labelOverride struct {
+	component, op, size string
+}
+
+var benchLabels = map[string]labelOverride{
+	"BenchmarkListView100":         {"list", "view", "100"},
+	"BenchmarkListCreate10":        {"list", "create", "10"},
+	"BenchmarkListCreate100":       {"list", "create", "100"},
+	"BenchmarkListCreate1000":      {"list", "create", "1000"},
+	"BenchmarkListNavigate100":     {"list", "navigate", "100"},
+	"BenchmarkListFilter100":       {"list", "filter", "100"},
+	"BenchmarkTableView10":         {"table", "view", "10"},
+	"BenchmarkTableView100":        {"table", "view", "100"},
+	"BenchmarkTableView1000":       {"table", "view", "1000"},
+	"BenchmarkTableNavigate":       {"table", "navigate", "n/a"},
+	"BenchmarkViewportRender100":   {"viewport", "render", "100"},
+	"BenchmarkViewportRender1000":  {"viewport", "render", "1000"},
+	"BenchmarkViewportRender10000": {"viewport", "render", "10000"},
+	"BenchmarkViewportScrollOps":   {"viewport", "scroll", "n/a"},
+	"BenchmarkSingleMessage":       {"core", "update", "1"},
+	"BenchmarkMessages1000":        {"core", "update", "1000"},
+	"BenchmarkMessages1000Mixed":   {"core", "update_mixed", "1000"},
+	"BenchmarkSimpleView":          {"core", "view", "1"},
+	"BenchmarkRenderShortSimple":   {"style", "render", "short"},
+	"BenchmarkRenderMediumSimple":  {"style", "render", "medium"},
+	"BenchmarkRenderLongSimple":    {"style", "render", "long"},
+	"BenchmarkRenderSmall":         {"markdown", "render", "small"},
+	"BenchmarkRenderMedium":        {"markdown", "render", "medium"},
+	"BenchmarkRenderLarge":         {"markdown", "render", "large"},
+}
+
+var trailingDigitsRE = regexp.MustCompile(`^(.*?)(\d+)$`)
+
+// LabelsForBenchmark returns the component/op/size labels for a benchmark
+// name, consulting benchLabels first and falling back to splitting off a
+// trailing size from the name (e.g. "BenchmarkFooBar42" -> component
+// "FooBar", size "42") with op "run" when the name isn't curated.
+func LabelsForBenchmark(name string) (component, op, size string) {
+	if l, ok := benchLabels[name]; ok {
+		return l.component, l.op, l.size
+	}
+	trimmed := strings.TrimPrefix(name, "Benchmark")
+	if m := trailingDigitsRE.FindStringSubmatch(trimmed); m != nil {
+		return m[1], "run", m[2]
+	}
+	return trimmed, "run", "n/a"
+}
+
+// BenchResult is one benchmark's outcome, labeled to match the same
+// series a prospective Rust conformance harness would report.
+type BenchResult struct {
+	Crate       string
+	Component   string
+	Op          string
+	Size        string
+	Lang        string
+	NsPerOp     float64
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+// labels builds the result's Prometheus label set.
+func (r BenchResult) labels() string {
+	return fmt.Sprintf(`crate=%q,component=%q,op=%q,size=%q,lang=%q`, r.Crate, r.Component, r.Op, r.Size, r.Lang)
+}
+
+// ToResults attaches crate and lang="go" to each RawResult, deriving
+// component/op/size via LabelsForBenchmark.
+func ToResults(crate string, raws []RawResult) []BenchResult {
+	results := make([]BenchResult, len(raws))
+	for i, raw := range raws {
+		component, op, size := LabelsForBenchmark(raw.Name)
+		results[i] = BenchResult{
+			Crate:       crate,
+			Component:   component,
+			Op:          op,
+			Size:        size,
+			Lang:        "go",
+			NsPerOp:     raw.NsPerOp,
+			AllocsPerOp: raw.AllocsPerOp,
+			BytesPerOp:  raw.BytesPerOp,
+		}
+	}
+	return results
+}
+
+// FormatPrometheus renders results as Prometheus text-exposition format,
+// one metric family each for ns_per_op, allocs_per_op, and bytes_per_op.
+func FormatPrometheus(results []BenchResult) string {
+	var b strings.Builder
+	writeFamily(&b, "charmed_bench_ns_per_op", "Nanoseconds per benchmark operation.", results,
+		func(r BenchResult) float64 { return r.NsPerOp })
+	writeFamily(&b, "charmed_bench_allocs_per_op", "Heap allocations per benchmark operation.", results,
+		func(r BenchResult) float64 { return float64(r.AllocsPerOp) })
+	writeFamily(&b, "charmed_bench_bytes_per_op", "Heap bytes allocated per benchmark operation.", results,
+		func(r BenchResult) float64 { return float64(r.BytesPerOp) })
+	return b.String()
+}
+
+func writeFamily(b *strings.Builder, name, help string, results []BenchResult, value func(BenchResult) float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	sorted := make([]BenchResult, len(results))
+	copy(sorted, results)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].labels() < sorted[j].labels() })
+	for _, r := range sorted {
+		fmt.Fprintf(b, "%s{%s} %v\n", name, r.labels(), value(r))
+	}
+}
+
+// ExportProm renders results as Prometheus text and writes it to
+// <outputDir>/<crate>.prom, creating outputDir if it doesn't already exist.
+func ExportProm(outputDir, crate string, results []BenchResult) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("benchreport: creating output dir: %w", err)
+	}
+	path := filepath.Join(outputDir, crate+".prom")
+	if err := os.WriteFile(path, []byte(FormatPrometheus(results)), 0o644); err != nil {
+		return fmt.Errorf("benchreport: writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// PushToGateway pushes results to a Prometheus Pushgateway instance under
+// job, using the gateway's PUT endpoint -- which replaces any metrics
+// previously pushed under the same job rather than accumulating them, so
+// re-running a benchmark doesn't leave stale series behind.
+func PushToGateway(gateway, job string, results []BenchResult) error {
+	url := strings.TrimRight(gateway, "/") + "/metrics/job/" + job
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(FormatPrometheus(results)))
+	if err != nil {
+		return fmt.Errorf("benchreport: building pushgateway request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("benchreport: pushing to gateway: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("benchreport: pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package benchreport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RegressionThreshold is the default percentage increase CompareReport
+// flags as a regression; CompareReportWithThreshold overrides it per call.
+var RegressionThreshold = 10.0
+
+// Regression is one metric series whose value rose by more than the
+// configured threshold between a baseline and a current capture.
+type Regression struct {
+	Metric        string
+	Labels        map[string]string
+	Baseline      float64
+	Current       float64
+	PercentChange float64
+}
+
+// Report is the result of comparing two Prometheus text-exposition
+// captures, ordered worst-regression first.
+type Report struct {
+	Regressions []Regression
+}
+
+type sample struct {
+	metric string
+	labels map[string]string
+	value  float64
+}
+
+var promLineRE = regexp.MustCompile(`^(\w+)\{([^}]*)\}\s+([0-9eE+\-.]+)\s*$`)
+
+func parsePromText(r io.Reader) (map[string]sample, error) {
+	samples := make(map[string]sample)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m := promLineRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		metric, labelStr, valStr := m[1], m[2], m[3]
+		value, err := strconv.ParseFloat(valStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("benchreport: parsing value %q: %w", valStr, err)
+		}
+		key := metric + "{" + labelStr + "}"
+		samples[key] = sample{metric: metric, labels: parsePromLabels(labelStr), value: value}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("benchreport: scanning prometheus text: %w", err)
+	}
+	return samples, nil
+}
+
+func parsePromLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		labels[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+	return labels
+}
+
+// CompareReport parses baseline and current Prometheus text-exposition
+// captures and flags any matching series whose value rose by more than
+// RegressionThreshold percent.
+func CompareReport(baseline, current io.Reader) (Report, error) {
+	return CompareReportWithThreshold(baseline, current, RegressionThreshold)
+}
+
+// CompareReportWithThreshold is CompareReport with an explicit percentage
+// threshold, for callers that want a tighter or looser drift budget than
+// RegressionThreshold.
+func CompareReportWithThreshold(baseline, current io.Reader, thresholdPercent float64) (Report, error) {
+	baseSamples, err := parsePromText(baseline)
+	if err != nil {
+		return Report{}, fmt.Errorf("benchreport: parsing baseline: %w", err)
+	}
+	curSamples, err := parsePromText(current)
+	if err != nil {
+		return Report{}, fmt.Errorf("benchreport: parsing current: %w", err)
+	}
+
+	var report Report
+	for key, cur := range curSamples {
+		base, ok := baseSamples[key]
+		if !ok || base.value == 0 {
+			continue
+		}
+		pct := (cur.value - base.value) / base.value * 100
+		if pct > thresholdPercent {
+			report.Regressions = append(report.Regressions, Regression{
+				Metric:        cur.metric,
+				Labels:        cur.labels,
+				Baseline:      base.value,
+				Current:       cur.value,
+				PercentChange: pct,
+			})
+		}
+	}
+	sort.Slice(report.Regressions, func(i, j int) bool {
+		return report.Regressions[i].PercentChange > report.Regressions[j].PercentChange
+	})
+	return report, nil
+}
@@ -0,0 +1,38 @@
+package benchreport
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportProm renders results as Prometheus text-exposition format and
+// writes it to <dir>/<crate>.prom, next to that crate's capture fixtures.
+func ExportProm(dir, crate string, results []BenchResult) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("benchreport: creating output directory: %w", err)
+	}
+	path := filepath.Join(dir, crate+".prom")
+	if err := os.WriteFile(path, []byte(FormatPrometheus(results)), 0644); err != nil {
+		return fmt.Errorf("benchreport: writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// PushToGateway pushes results to a Prometheus Pushgateway instance at
+// gatewayURL under job, so CI can track Go vs. Rust benchmark drift over
+// time instead of only diffing one-shot .prom snapshots.
+func PushToGateway(gatewayURL, job string, results []BenchResult) error {
+	url := strings.TrimRight(gatewayURL, "/") + "/metrics/job/" + job
+	resp, err := http.Post(url, "text/plain; version=0.0.4", strings.NewReader(FormatPrometheus(results)))
+	if err != nil {
+		return fmt.Errorf("benchreport: pushing to gateway: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("benchreport: pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
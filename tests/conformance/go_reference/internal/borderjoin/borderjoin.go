@@ -0,0 +1,213 @@
+// Package borderjoin computes the junction glyph two adjacent bordered
+// blocks' touching border runes should merge into (a T-junction or a
+// cross) instead of doubling up, the way lipgloss.JoinHorizontal and
+// JoinVertical render them today. The glyph tables are modeled on
+// stanza's Decor naming (up_bold_right_bold_down_bold_left_bold -> '┼',
+// up_bold_right_bold_down_bold -> '├', and so on), with parallel tables
+// for thin, thick, and double border weights.
+package borderjoin
+
+// Weight is a border line weight: none (no border there at all), thin
+// (lipgloss's normal/rounded borders, which share the same line glyphs),
+// thick, or double.
+type Weight int
+
+const (
+	WeightNone Weight = iota
+	WeightThin
+	WeightThick
+	WeightDouble
+)
+
+// Arm bitmask values identifying which of the four cardinal directions a
+// junction glyph extends into.
+const (
+	armUp = 1 << iota
+	armRight
+	armDown
+	armLeft
+)
+
+// Junction describes the four arms meeting at one grid cell, each with
+// its own weight (WeightNone if that arm is absent).
+type Junction struct {
+	Up    Weight
+	Right Weight
+	Down  Weight
+	Left  Weight
+}
+
+// thinTable, thickTable, and doubleTable map an arm bitmask to the
+// box-drawing rune for that shape, in the given weight. Single-arm caps
+// (e.g. a lone "up" arm) have no standard double-line glyph, so
+// doubleTable falls back to the thin cap glyph for those masks -- double
+// borders don't define their own.
+var thinTable = map[int]rune{
+	0:                                    ' ',
+	armUp:                                '╵',
+	armRight:                             '╶',
+	armDown:                              '╷',
+	armLeft:                              '╴',
+	armUp | armRight:                     '└',
+	armUp | armDown:                      '│',
+	armUp | armLeft:                      '┘',
+	armRight | armDown:                   '┌',
+	armRight | armLeft:                   '─',
+	armDown | armLeft:                    '┐',
+	armUp | armRight | armDown:           '├',
+	armUp | armRight | armLeft:           '┴',
+	armUp | armDown | armLeft:            '┤',
+	armRight | armDown | armLeft:         '┬',
+	armUp | armRight | armDown | armLeft: '┼',
+}
+
+var thickTable = map[int]rune{
+	0:                                    ' ',
+	armUp:                                '╹',
+	armRight:                             '╺',
+	armDown:                              '╻',
+	armLeft:                              '╸',
+	armUp | armRight:                     '┗',
+	armUp | armDown:                      '┃',
+	armUp | armLeft:                      '┛',
+	armRight | armDown:                   '┏',
+	armRight | armLeft:                   '━',
+	armDown | armLeft:                    '┓',
+	armUp | armRight | armDown:           '┣',
+	armUp | armRight | armLeft:           '┻',
+	armUp | armDown | armLeft:            '┫',
+	armRight | armDown | armLeft:         '┳',
+	armUp | armRight | armDown | armLeft: '╋',
+}
+
+var doubleTable = map[int]rune{
+	0:                                    ' ',
+	armUp:                                thinTable[armUp],
+	armRight:                             thinTable[armRight],
+	armDown:                              thinTable[armDown],
+	armLeft:                              thinTable[armLeft],
+	armUp | armRight:                     '╚',
+	armUp | armDown:                      '║',
+	armUp | armLeft:                      '╝',
+	armRight | armDown:                   '╔',
+	armRight | armLeft:                   '═',
+	armDown | armLeft:                    '╗',
+	armUp | armRight | armDown:           '╠',
+	armUp | armRight | armLeft:           '╩',
+	armUp | armDown | armLeft:            '╣',
+	armRight | armDown | armLeft:         '╦',
+	armUp | armRight | armDown | armLeft: '╬',
+}
+
+func tableFor(w Weight) map[int]rune {
+	switch w {
+	case WeightThick:
+		return thickTable
+	case WeightDouble:
+		return doubleTable
+	default:
+		return thinTable
+	}
+}
+
+// invert builds a rune -> arm-bitmask lookup from a weight's forward
+// table, for recognizing a border rune already present in a grid.
+func invert(table map[int]rune) map[rune]int {
+	out := make(map[rune]int, len(table))
+	for mask, r := range table {
+		out[r] = mask
+	}
+	return out
+}
+
+var (
+	thinMaskByRune   = invert(thinTable)
+	thickMaskByRune  = invert(thickTable)
+	doubleMaskByRune = invert(doubleTable)
+)
+
+// Classify recognizes r as a border rune, returning the arms it extends
+// (as a bitmask of armUp/armRight/armDown/armLeft) and the weight family
+// it belongs to. ok is false if r is not a recognized border rune.
+func Classify(r rune) (mask int, weight Weight, ok bool) {
+	if m, found := thinMaskByRune[r]; found && r != ' ' {
+		return m, WeightThin, true
+	}
+	if m, found := thickMaskByRune[r]; found && r != ' ' {
+		return m, WeightThick, true
+	}
+	if m, found := doubleMaskByRune[r]; found && r != ' ' {
+		return m, WeightDouble, true
+	}
+	if m, found := roundedCornerMask[r]; found {
+		return m, WeightThin, true
+	}
+	return 0, WeightNone, false
+}
+
+// roundedCornerMask recognizes lipgloss's rounded-border corners as thin
+// runes. thinTable has no entry for them, since Glyph never emits them.
+var roundedCornerMask = map[rune]int{
+	'╭': armRight | armDown,
+	'╮': armDown | armLeft,
+	'╰': armUp | armRight,
+	'╯': armUp | armLeft,
+}
+
+// precedence ranks weights for dominantWeight's mixed-weight fallback:
+// thick visually dominates double, which dominates thin.
+func precedence(w Weight) int {
+	switch w {
+	case WeightThick:
+		return 2
+	case WeightDouble:
+		return 1
+	case WeightThin:
+		return 0
+	default:
+		return -1
+	}
+}
+
+// dominantWeight resolves a Junction whose arms carry different weights
+// (a "thin-meets-thick" case, say) down to the single weight used to
+// pick the glyph. This is a deliberate simplification rather than the
+// exact mixed-weight glyphs Unicode's box-drawing block defines for some
+// thin/thick combinations (e.g. U+253F): it picks the highest-precedence
+// weight among the present arms and renders the junction as if every arm
+// had that weight.
+func dominantWeight(j Junction) Weight {
+	dominant := WeightNone
+	for _, w := range [...]Weight{j.Up, j.Right, j.Down, j.Left} {
+		if w == WeightNone {
+			continue
+		}
+		if dominant == WeightNone || precedence(w) > precedence(dominant) {
+			dominant = w
+		}
+	}
+	return dominant
+}
+
+// Glyph looks up the junction glyph for j. If j's arms carry more than
+// one weight, the glyph is rendered using dominantWeight's resolution
+// (see its doc comment). ok is false only for a mask the table doesn't
+// define, which no combination of the four arms produces; a junction
+// with no arms set yields a space.
+func Glyph(j Junction) (rune, bool) {
+	mask := 0
+	if j.Up != WeightNone {
+		mask |= armUp
+	}
+	if j.Right != WeightNone {
+		mask |= armRight
+	}
+	if j.Down != WeightNone {
+		mask |= armDown
+	}
+	if j.Left != WeightNone {
+		mask |= armLeft
+	}
+	r, ok := tableFor(dominantWeight(j))[mask]
+	return r, ok
+}
@@ -0,0 +1,155 @@
+package borderjoin
+
+import "strings"
+
+// resolveShared combines two weights that both claim the same arm (e.g.
+// the "down" arm at a horizontal seam's top row, which both the left and
+// right block's top-right/top-left corners extend into): if only one
+// side actually has that arm, its weight wins outright; if both do,
+// dominantWeight's precedence decides.
+func resolveShared(w1 Weight, has1 bool, w2 Weight, has2 bool) Weight {
+	switch {
+	case has1 && has2:
+		if precedence(w2) > precedence(w1) {
+			return w2
+		}
+		return w1
+	case has1:
+		return w1
+	case has2:
+		return w2
+	default:
+		return WeightNone
+	}
+}
+
+// mergeHorizontalPoint combines the rune ending a left block's row with
+// the rune starting the adjacent right block's row into the junction
+// that should replace both. ok is false if either rune isn't a
+// recognized border rune, in which case the row isn't part of a border
+// (e.g. interior text) and should be left untouched.
+func mergeHorizontalPoint(leftRune, rightRune rune) (rune, bool) {
+	lMask, lWeight, lOK := Classify(leftRune)
+	rMask, rWeight, rOK := Classify(rightRune)
+	if !lOK || !rOK {
+		return 0, false
+	}
+
+	lUp, lDown := lMask&armUp != 0, lMask&armDown != 0
+	rUp, rDown := rMask&armUp != 0, rMask&armDown != 0
+	lLeft := lMask&armLeft != 0
+	rRight := rMask&armRight != 0
+
+	j := Junction{
+		Up:   resolveShared(lWeight, lUp, rWeight, rUp),
+		Down: resolveShared(lWeight, lDown, rWeight, rDown),
+	}
+	if lLeft {
+		j.Left = lWeight
+	}
+	if rRight {
+		j.Right = rWeight
+	}
+
+	return Glyph(j)
+}
+
+// mergeVerticalPoint combines the rune ending a top block's column with
+// the rune starting the adjacent bottom block's column into the
+// junction that should replace both. See mergeHorizontalPoint.
+func mergeVerticalPoint(topRune, bottomRune rune) (rune, bool) {
+	tMask, tWeight, tOK := Classify(topRune)
+	bMask, bWeight, bOK := Classify(bottomRune)
+	if !tOK || !bOK {
+		return 0, false
+	}
+
+	tLeft, tRight := tMask&armLeft != 0, tMask&armRight != 0
+	bLeft, bRight := bMask&armLeft != 0, bMask&armRight != 0
+	tUp := tMask&armUp != 0
+	bDown := bMask&armDown != 0
+
+	j := Junction{
+		Left:  resolveShared(tWeight, tLeft, bWeight, bLeft),
+		Right: resolveShared(tWeight, tRight, bWeight, bRight),
+	}
+	if tUp {
+		j.Up = tWeight
+	}
+	if bDown {
+		j.Down = bWeight
+	}
+
+	return Glyph(j)
+}
+
+// MergeHorizontal corrects the seam in joined, the output of rendering
+// two bordered blocks side by side with no gap between them (e.g. via
+// lipgloss.JoinHorizontal), where leftWidth is the left block's rendered
+// width in runes. Each row's doubled-up border runes at the seam --
+// the left block's right edge immediately followed by the right block's
+// left edge -- are collapsed into the single junction glyph they should
+// have been. Rows that aren't border rows (interior text on both sides)
+// are left untouched.
+func MergeHorizontal(joined string, leftWidth int) string {
+	lines := strings.Split(joined, "\n")
+	for i, line := range lines {
+		runes := []rune(line)
+		if leftWidth <= 0 || leftWidth >= len(runes) {
+			continue
+		}
+		merged, ok := mergeHorizontalPoint(runes[leftWidth-1], runes[leftWidth])
+		if !ok {
+			continue
+		}
+		out := make([]rune, 0, len(runes)-1)
+		out = append(out, runes[:leftWidth-1]...)
+		out = append(out, merged)
+		out = append(out, runes[leftWidth+1:]...)
+		lines[i] = string(out)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MergeVertical corrects the seam in joined, the output of rendering two
+// bordered blocks stacked with no gap between them (e.g. via
+// lipgloss.JoinVertical), where topHeight is the top block's rendered
+// height in lines. The top block's bottom border row and the bottom
+// block's top border row, which sit directly on top of each other, are
+// collapsed column by column into the single junction-glyph row they
+// should have been. Columns where the two sides aren't both recognized
+// border runes (e.g. one side is plain padding, or the two blocks have
+// different widths so a column only exists on one side) get no junction:
+// they keep the top row's rune, or the bottom row's where the top row has
+// a space or no column at all -- this is what lets
+// differently-shaped rows in a pooltable-style grid stack without every
+// column needing to line up.
+func MergeVertical(joined string, topHeight int) string {
+	lines := strings.Split(joined, "\n")
+	if topHeight <= 0 || topHeight >= len(lines) {
+		return joined
+	}
+	topLine := []rune(lines[topHeight-1])
+	bottomLine := []rune(lines[topHeight])
+
+	width := len(topLine)
+	if len(bottomLine) < width {
+		width = len(bottomLine)
+	}
+
+	merged := append([]rune(nil), topLine...)
+	if len(bottomLine) > len(merged) {
+		merged = append(merged, bottomLine[len(merged):]...)
+	}
+	for col := 0; col < width; col++ {
+		if r, ok := mergeVerticalPoint(topLine[col], bottomLine[col]); ok {
+			merged[col] = r
+		} else if topLine[col] == ' ' {
+			merged[col] = bottomLine[col]
+		}
+	}
+
+	lines[topHeight-1] = string(merged)
+	lines = append(lines[:topHeight], lines[topHeight+1:]...)
+	return strings.Join(lines, "\n")
+}
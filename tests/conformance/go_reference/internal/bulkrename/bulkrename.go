@@ -0,0 +1,252 @@
+// Package bulkrename implements a $EDITOR-driven bulk rename of a set of
+// filepicker-selected paths: write the paths to a tempfile, let the user
+// edit it, then validate and apply the edits as a safe two-phase rename.
+// filepicker.Model has no such subsystem today, so this stands in for
+// bulkrename.Run(ctx, paths []string) tea.Cmd.
+package bulkrename
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BulkRenameResultMsg reports the outcome of a bulk rename: Renamed maps
+// each source path to its new path when the whole rename succeeded, and is
+// nil otherwise; Errors collects editor, validation or filesystem errors.
+// A validation error means no rename was attempted, but a filesystem error
+// in either rename phase can come after some renames were already applied,
+// and those are not reported in Renamed.
+type BulkRenameResultMsg struct {
+	Renamed map[string]string
+	Errors  []error
+}
+
+// Run writes paths to a tempfile, opens it in $EDITOR (falling back to
+// "vi") via tea.ExecProcess, then parses, validates, and applies the
+// edited result as a two-phase rename once the editor exits.
+func Run(ctx context.Context, paths []string) tea.Cmd {
+	tmpFile, err := writeTempFile(paths)
+	if err != nil {
+		return func() tea.Msg { return BulkRenameResultMsg{Errors: []error{err}} }
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	cmd := exec.CommandContext(ctx, editor, tmpFile)
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(tmpFile)
+		if err != nil {
+			return BulkRenameResultMsg{Errors: []error{err}}
+		}
+
+		edited, err := os.ReadFile(tmpFile)
+		if err != nil {
+			return BulkRenameResultMsg{Errors: []error{err}}
+		}
+
+		targets := ParseEditedLines(string(edited))
+		renamed, errs := Apply(paths, targets, pathExists)
+		return BulkRenameResultMsg{Renamed: renamed, Errors: errs}
+	})
+}
+
+func writeTempFile(paths []string) (string, error) {
+	f, err := os.CreateTemp("", "bulkrename-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, p := range paths {
+		fmt.Fprintln(w, p)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// ParseEditedLines splits an edited tempfile's contents into one target
+// path per line, trimming surrounding whitespace and dropping a single
+// trailing blank line left by the editor.
+func ParseEditedLines(content string) []string {
+	lines := strings.Split(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = strings.TrimSpace(l)
+	}
+	return out
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ValidateCounts checks that the edited file still has one line per
+// source path; a user who deletes or adds a line gets a clear error
+// instead of a silently misaligned rename.
+func ValidateCounts(sources, targets []string) error {
+	if len(sources) != len(targets) {
+		return fmt.Errorf("bulkrename: expected %d lines, got %d", len(sources), len(targets))
+	}
+	return nil
+}
+
+// DetectCollisions validates a proposed sources->targets rename: no two
+// targets may collide with each other, and no target may collide with an
+// existing path that isn't itself being renamed away (a target equal to
+// another entry's source is fine -- that's a cycle, not a collision).
+func DetectCollisions(sources, targets []string, exists func(string) bool) []error {
+	var errs []error
+
+	sourceSet := make(map[string]bool, len(sources))
+	for _, s := range sources {
+		sourceSet[s] = true
+	}
+
+	seenTargets := make(map[string]string, len(targets))
+	for i, t := range targets {
+		if t == "" {
+			errs = append(errs, fmt.Errorf("bulkrename: empty target for %q", sources[i]))
+			continue
+		}
+		if prevSource, ok := seenTargets[t]; ok {
+			errs = append(errs, fmt.Errorf("bulkrename: %q and %q both rename to %q", prevSource, sources[i], t))
+			continue
+		}
+		seenTargets[t] = sources[i]
+
+		if t == sources[i] {
+			continue // no-op rename
+		}
+		if !sourceSet[t] && exists(t) {
+			errs = append(errs, fmt.Errorf("bulkrename: target %q already exists", t))
+		}
+	}
+	return errs
+}
+
+// Step is one leg of a two-phase rename plan.
+type Step struct {
+	From string
+	To   string
+}
+
+// PlanTwoPhase builds a rename plan that's safe even when targets form a
+// cycle (e.g. a->b, b->a) or an ordinary rename chain (e.g. a->b, b->c):
+// every source whose own path is also somebody else's target is first
+// renamed to a unique, collision-free temp name in the same directory, so
+// its content can't be overwritten before it's read; only once every such
+// source has vacated its original name does phase two move the temp names
+// (and the untouched sources) to their real targets. Because every path
+// that's about to be overwritten is vacated up front, phase two's step
+// order no longer matters -- a requirement a fix here must preserve, since
+// chains longer than two sources depend on it.
+func PlanTwoPhase(sources, targets []string) (phase1, phase2 []Step) {
+	targetSet := make(map[string]bool, len(sources))
+	for i, s := range sources {
+		if s == targets[i] {
+			continue // no-op, doesn't claim its target path
+		}
+		targetSet[targets[i]] = true
+	}
+
+	tempNames := make([]string, len(sources))
+	for i, s := range sources {
+		if s == targets[i] {
+			continue // no-op, neither phase touches it
+		}
+		if targetSet[s] {
+			dir, base := filepath.Split(s)
+			tempNames[i] = filepath.Join(dir, fmt.Sprintf(".bulkrename.tmp.%d.%s", i, base))
+		}
+	}
+
+	for i, s := range sources {
+		if s == targets[i] {
+			continue
+		}
+		if tempNames[i] != "" {
+			phase1 = append(phase1, Step{From: s, To: tempNames[i]})
+		}
+	}
+	for i, s := range sources {
+		if s == targets[i] {
+			continue
+		}
+		from := s
+		if tempNames[i] != "" {
+			from = tempNames[i]
+		}
+		phase2 = append(phase2, Step{From: from, To: targets[i]})
+	}
+	return phase1, phase2
+}
+
+// Apply validates sources against the edited targets and, if valid,
+// performs the two-phase rename, returning the successfully applied
+// source->target mapping. On any validation error, no renames are
+// performed and Renamed is nil.
+func Apply(sources, targets []string, exists func(string) bool) (map[string]string, []error) {
+	if err := ValidateCounts(sources, targets); err != nil {
+		return nil, []error{err}
+	}
+	if errs := DetectCollisions(sources, targets, exists); len(errs) > 0 {
+		return nil, errs
+	}
+
+	phase1, phase2 := PlanTwoPhase(sources, targets)
+
+	var errs []error
+	for _, step := range phase1 {
+		if err := os.Rename(step.From, step.To); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	renamed := make(map[string]string, len(sources))
+	for _, step := range phase2 {
+		if err := os.Rename(step.From, step.To); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	for i, s := range sources {
+		renamed[s] = targets[i]
+	}
+	return renamed, nil
+}
+
+// SortedKeys is a small helper for callers that want deterministic
+// Renamed output, since map iteration order isn't stable.
+func SortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,22 @@
+package capture
+
+// AdaptiveInput is the input to an adaptive/complete color render test:
+// one of lipgloss's background-aware color types (AdaptiveColor,
+// CompleteColor, or CompleteAdaptiveColor), rendered under a simulated
+// terminal profile and background. ColorKind identifies which of the
+// three color types was used; Profile is one of "ascii", "ansi",
+// "ansi256", or "truecolor", matching the termenv.Profile the Go
+// reference rendered under.
+type AdaptiveInput struct {
+	ColorKind         string `json:"color_kind"`
+	Text              string `json:"text"`
+	Profile           string `json:"profile"`
+	HasDarkBackground bool   `json:"has_dark_background"`
+}
+
+// AdaptiveOutput is an adaptive/complete color test's result: the exact
+// escape sequence lipgloss produced for that profile/background
+// combination.
+type AdaptiveOutput struct {
+	Rendered string `json:"rendered"`
+}
@@ -0,0 +1,94 @@
+package capture
+
+import "math"
+
+// AnalyticSpringInput is the input to AnalyticSpring: a Juckett-style
+// closed-form damped harmonic oscillator, independent of harmonica's
+// semi-implicit Euler integrator (see SpringInput), so a Rust port can be
+// checked against an analytic reference that carries no integrator drift
+// of its own.
+type AnalyticSpringInput struct {
+	AngularFreq  float64 `json:"angular_freq"`
+	DampingRatio float64 `json:"damping_ratio"`
+	CurrentPos   float64 `json:"current_pos"`
+	Velocity     float64 `json:"velocity"`
+	TargetPos    float64 `json:"target_pos"`
+	DeltaTime    float64 `json:"delta_time"`
+}
+
+// AnalyticSpringOutput is AnalyticSpring's result.
+type AnalyticSpringOutput struct {
+	NewPos      float64 `json:"new_pos"`
+	NewVelocity float64 `json:"new_velocity"`
+}
+
+// criticalDampingEpsilon is how close the damping ratio must be to 1
+// before AnalyticSpring switches to the critically-damped closed form,
+// which the over/under-damped forms are singular or numerically
+// unstable near.
+const criticalDampingEpsilon = 1e-5
+
+// nearZeroAngularFreq is the threshold below which AnalyticSpring treats
+// the spring as having no restoring force at all, since both the
+// over-damped and under-damped closed forms divide by a quantity that
+// goes to zero along with the angular frequency.
+const nearZeroAngularFreq = 1e-9
+
+// AnalyticSpring evolves a damped harmonic oscillator by exactly dt using
+// the closed-form solution for in's damping ratio, branching on whether
+// it is over-damped (ζ>1), critically damped (ζ≈1), or under-damped
+// (ζ<1).
+func AnalyticSpring(in AnalyticSpringInput) AnalyticSpringOutput {
+	omega := in.AngularFreq
+	zeta := in.DampingRatio
+	x0 := in.CurrentPos - in.TargetPos
+	v0 := in.Velocity
+	dt := in.DeltaTime
+
+	if omega < nearZeroAngularFreq {
+		// No restoring force: the "spring" is just free motion at
+		// constant velocity.
+		return AnalyticSpringOutput{
+			NewPos:      in.TargetPos + x0 + v0*dt,
+			NewVelocity: v0,
+		}
+	}
+
+	var x, v float64
+	switch {
+	case zeta > 1+criticalDampingEpsilon:
+		// Over-damped: x(t) = A*e^(z1*t) + B*e^(z2*t).
+		r := math.Sqrt(zeta*zeta - 1)
+		z1 := -omega * (zeta - r)
+		z2 := -omega * (zeta + r)
+		a := (v0 - x0*z2) / (z1 - z2)
+		b := x0 - a
+		e1 := math.Exp(z1 * dt)
+		e2 := math.Exp(z2 * dt)
+		x = a*e1 + b*e2
+		v = a*z1*e1 + b*z2*e2
+
+	case zeta < 1-criticalDampingEpsilon:
+		// Under-damped: x(t) = e^(-α*t)*(x0*cos(β*t) + c2*sin(β*t)).
+		alpha := omega * zeta
+		beta := omega * math.Sqrt(1-zeta*zeta)
+		c2 := (v0 + alpha*x0) / beta
+		decay := math.Exp(-alpha * dt)
+		cosBt := math.Cos(beta * dt)
+		sinBt := math.Sin(beta * dt)
+		x = decay * (x0*cosBt + c2*sinBt)
+		v = decay * (v0*cosBt - (alpha*c2+beta*x0)*sinBt)
+
+	default:
+		// Critically damped: x(t) = (x0 + c2*t)*e^(-ω*t).
+		c2 := v0 + omega*x0
+		decay := math.Exp(-omega * dt)
+		x = (x0 + c2*dt) * decay
+		v = decay * (v0 - omega*c2*dt)
+	}
+
+	return AnalyticSpringOutput{
+		NewPos:      in.TargetPos + x,
+		NewVelocity: v,
+	}
+}
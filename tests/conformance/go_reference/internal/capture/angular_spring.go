@@ -0,0 +1,49 @@
+package capture
+
+import "math"
+
+// AngularSpringInput is the input to a single angular spring step:
+// harmonica's Spring treats its coordinate as a plain scalar, so driving
+// it directly with an angle produces the long way around whenever
+// current and target straddle the 0/2π seam. AngularSpringInput
+// documents the wrap-around-aware API surface a UI rotation animation
+// (compass needle, spinner) actually needs instead.
+type AngularSpringInput struct {
+	Frequency       float64 `json:"frequency"`
+	Damping         float64 `json:"damping"`
+	CurrentAngle    float64 `json:"current_angle"`
+	TargetAngle     float64 `json:"target_angle"`
+	AngularVelocity float64 `json:"angular_velocity"`
+	DeltaTime       float64 `json:"delta_time"`
+}
+
+// AngularSpringOutput is an angular spring step's result. WrappedDelta is
+// the shortest-path delta (target - current, normalized into (-π, π])
+// actually fed to the underlying spring, included so the Rust port can
+// confirm it chose the same direction around the circle.
+type AngularSpringOutput struct {
+	NewAngle           float64 `json:"new_angle"`
+	NewAngularVelocity float64 `json:"new_angular_velocity"`
+	WrappedDelta       float64 `json:"wrapped_delta"`
+}
+
+// WrapDeltaToShortestPath normalizes target-current into (-π, π], so a
+// spring driven by it always takes the shorter way around the circle.
+func WrapDeltaToShortestPath(current, target float64) float64 {
+	delta := math.Mod(target-current, 2*math.Pi)
+	if delta > math.Pi {
+		delta -= 2 * math.Pi
+	} else if delta <= -math.Pi {
+		delta += 2 * math.Pi
+	}
+	return delta
+}
+
+// WrapAngle normalizes theta into [0, 2π).
+func WrapAngle(theta float64) float64 {
+	theta = math.Mod(theta, 2*math.Pi)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+	return theta
+}
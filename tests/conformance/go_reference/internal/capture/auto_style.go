@@ -0,0 +1,28 @@
+package capture
+
+// AutoStyleEnv is the synthetic terminal environment an auto-style
+// test probes, mirroring termdetect.Env as plain JSON.
+type AutoStyleEnv struct {
+	ColorTerm     string `json:"colorterm"`
+	Term          string `json:"term"`
+	NoColor       string `json:"no_color"`
+	Clicolor      string `json:"clicolor"`
+	ClicolorForce string `json:"clicolor_force"`
+	Background    string `json:"background"`
+	ColorProfile  string `json:"color_profile"`
+}
+
+// AutoStyleInput is the input to an auto-style-selection test: the
+// environment vector glamour's style auto-detection would observe.
+type AutoStyleInput struct {
+	Env AutoStyleEnv `json:"env"`
+}
+
+// AutoStyleOutput is an auto-style-selection test's result: the style
+// name and color profile the environment resolved to, and glamour's
+// rendered output for a canonical document under that style.
+type AutoStyleOutput struct {
+	SelectedStyle   string `json:"selected_style"`
+	DetectedProfile string `json:"detected_profile"`
+	Rendered        string `json:"rendered"`
+}
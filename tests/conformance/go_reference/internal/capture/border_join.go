@@ -0,0 +1,23 @@
+package capture
+
+// BorderJoinInput is the input to a border-join test: two bordered
+// blocks placed adjacently with no gap (via lipgloss.JoinHorizontal or
+// JoinVertical), whose touching border runes should merge into proper
+// T-junctions and crosses instead of doubling up. See
+// internal/borderjoin for the glyph tables and merge algorithm.
+type BorderJoinInput struct {
+	Direction         string `json:"direction"`
+	LeftOrTopStyle    string `json:"left_or_top_style"`
+	RightOrBottom     string `json:"right_or_bottom_style"`
+	LeftOrTopText     string `json:"left_or_top_text"`
+	RightOrBottomText string `json:"right_or_bottom_text"`
+}
+
+// BorderJoinOutput is a border-join test's result: the naive output
+// lipgloss produces today (doubled-up border runes at the seam) and the
+// corrected output after running it through internal/borderjoin's
+// post-processor.
+type BorderJoinOutput struct {
+	Naive     string `json:"naive"`
+	Corrected string `json:"corrected"`
+}
@@ -3,38 +3,41 @@
 package capture
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
+	"sync"
 	"time"
 )
 
 // FixtureMetadata contains metadata about the fixture set
 type FixtureMetadata struct {
-	Crate          string  `json:"crate"`
-	GoVersion      string  `json:"go_version"`
-	LibraryVersion string  `json:"library_version"`
-	CapturedAt     string  `json:"captured_at"`
-	Platform       *string `json:"platform,omitempty"`
-	Notes          *string `json:"notes,omitempty"`
+	Crate          string            `json:"crate"`
+	GoVersion      string            `json:"go_version"`
+	LibraryVersion string            `json:"library_version"`
+	CapturedAt     string            `json:"captured_at"`
+	Platform       *string           `json:"platform,omitempty"`
+	Notes          *string           `json:"notes,omitempty"`
+	Environment    map[string]string `json:"environment,omitempty"`
 }
 
 // TestFixture represents a single test case
 type TestFixture struct {
-	Name           string      `json:"name"`
-	Category       *string     `json:"category,omitempty"`
-	Input          interface{} `json:"input"`
-	ExpectedOutput interface{} `json:"expected_output"`
-	Notes          *string     `json:"notes,omitempty"`
-	Tags           []string    `json:"tags,omitempty"`
-	SkipReason     *string     `json:"skip_reason,omitempty"`
+	Name               string            `json:"name"`
+	Category           *string           `json:"category,omitempty"`
+	Input              interface{}       `json:"input"`
+	ExpectedOutput     interface{}       `json:"expected_output"`
+	Notes              *string           `json:"notes,omitempty"`
+	Tags               []string          `json:"tags,omitempty"`
+	SkipReason         *string           `json:"skip_reason,omitempty"`
+	EscapePreservation *bool             `json:"escape_preservation,omitempty"`
+	Environment        map[string]string `json:"environment,omitempty"`
 }
 
 // FixtureSet is a complete set of fixtures for a crate
 type FixtureSet struct {
 	Metadata FixtureMetadata `json:"metadata"`
 	Tests    []TestFixture   `json:"tests"`
+
+	mu   sync.Mutex
+	memo *MemoStore
 }
 
 // NewFixtureSet creates a new fixture set for a crate
@@ -50,9 +53,18 @@ func NewFixtureSet(crateName, libraryVersion string) *FixtureSet {
 	}
 }
 
+// appendTest adds t to Tests under fs's mutex, so capture binaries that
+// shard fixture generation across goroutines (e.g. one per table row
+// batch) can call the Add* methods concurrently without racing on Tests.
+func (fs *FixtureSet) appendTest(t TestFixture) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.Tests = append(fs.Tests, t)
+}
+
 // AddTest adds a test fixture to the set
 func (fs *FixtureSet) AddTest(name string, input, output interface{}) {
-	fs.Tests = append(fs.Tests, TestFixture{
+	fs.appendTest(TestFixture{
 		Name:           name,
 		Input:          input,
 		ExpectedOutput: output,
@@ -62,7 +74,7 @@ func (fs *FixtureSet) AddTest(name string, input, output interface{}) {
 // AddTestWithCategory adds a test fixture with a category
 func (fs *FixtureSet) AddTestWithCategory(name, category string, input, output interface{}) {
 	cat := category
-	fs.Tests = append(fs.Tests, TestFixture{
+	fs.appendTest(TestFixture{
 		Name:           name,
 		Category:       &cat,
 		Input:          input,
@@ -73,7 +85,7 @@ func (fs *FixtureSet) AddTestWithCategory(name, category string, input, output i
 // AddTestWithNotes adds a test fixture with notes
 func (fs *FixtureSet) AddTestWithNotes(name string, input, output interface{}, notes string) {
 	n := notes
-	fs.Tests = append(fs.Tests, TestFixture{
+	fs.appendTest(TestFixture{
 		Name:           name,
 		Input:          input,
 		ExpectedOutput: output,
@@ -81,26 +93,108 @@ func (fs *FixtureSet) AddTestWithNotes(name string, input, output interface{}, n
 	})
 }
 
-// WriteToFile writes the fixture set to a JSON file
-func (fs *FixtureSet) WriteToFile(outputDir string) error {
-	filename := filepath.Join(outputDir, fs.Metadata.Crate+".json")
-	data, err := json.MarshalIndent(fs, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal fixtures: %w", err)
+// AddTestWithEscapePreservation adds a test fixture that records whether
+// ANSI escape state (e.g. an SGR color) leaks across a render boundary,
+// such as a viewport scrolling mid-sequence.
+func (fs *FixtureSet) AddTestWithEscapePreservation(name string, input, output interface{}, leaksAcrossBoundary bool) {
+	leaks := leaksAcrossBoundary
+	fs.appendTest(TestFixture{
+		Name:               name,
+		Input:              input,
+		ExpectedOutput:     output,
+		EscapePreservation: &leaks,
+	})
+}
+
+// AddTestWithEnvironment adds a test fixture carrying its own per-fixture
+// environment (e.g. the simulated terminal profile and background a
+// color was rendered under), distinct from FixtureSet.SetEnvironment's
+// suite-wide environment: a Rust harness seeds this one per fixture,
+// immediately before replaying that specific test, rather than once for
+// the whole run.
+func (fs *FixtureSet) AddTestWithEnvironment(name string, input, output interface{}, env map[string]string) {
+	fs.appendTest(TestFixture{
+		Name:           name,
+		Input:          input,
+		ExpectedOutput: output,
+		Environment:    env,
+	})
+}
+
+// AddTestWithTags adds a test fixture labeled with tags, e.g. "fuzz" for a
+// property-generated case, so a Rust harness can filter generated fixtures
+// out of (or into) the hand-written conformance baseline independently.
+func (fs *FixtureSet) AddTestWithTags(name string, input, output interface{}, tags []string) {
+	fs.appendTest(TestFixture{
+		Name:           name,
+		Input:          input,
+		ExpectedOutput: output,
+		Tags:           tags,
+	})
+}
+
+// Memo returns fs's MemoStore, creating it on first use.
+func (fs *FixtureSet) Memo() *MemoStore {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.memo == nil {
+		fs.memo = NewMemoStore()
 	}
+	return fs.memo
+}
 
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+// AddComputed adds a fixture whose expected output is produced lazily:
+// produce runs at most once per distinct input across the whole
+// FixtureSet, keyed by a content hash of input, even when AddComputed is
+// called concurrently for the same input from multiple goroutines. This
+// lets a capture binary shard large fixture generation (e.g. bubbles
+// table with 1000 rows, viewport with 10000 lines) across goroutines
+// without redundantly recomputing identical inputs or racing to render
+// the same one twice.
+func (fs *FixtureSet) AddComputed(name, category string, input interface{}, produce func() (interface{}, error)) error {
+	key, err := HashInput(input)
+	if err != nil {
+		return err
 	}
 
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("failed to write fixture file: %w", err)
+	memo := fs.Memo()
+	output, err := memo.GetOrCreate(key, produce)
+	if err != nil {
+		return err
 	}
+	memo.recordName(key, name)
 
-	fmt.Printf("Wrote %d tests to %s\n", len(fs.Tests), filename)
+	fs.AddTestWithCategory(name, category, input, output)
 	return nil
 }
 
+// WriteMemoManifest writes the MemoStore's hash -> fixture names mapping
+// to <dir>/<crate>.memo-manifest.json, if AddComputed was ever called. It
+// is a no-op if no MemoStore was created.
+func (fs *FixtureSet) WriteMemoManifest(dir string) error {
+	fs.mu.Lock()
+	memo := fs.memo
+	fs.mu.Unlock()
+	if memo == nil {
+		return nil
+	}
+	return memo.WriteManifest(dir, fs.Metadata.Crate)
+}
+
+// SetEnvironment records the environment variables that were deterministically
+// seeded before capture, so a Rust port can reproduce the same rendering
+// conditions (e.g. glamour's style/color-profile auto-detection) instead of
+// inheriting whatever environment the conformance suite happens to run in.
+func (fs *FixtureSet) SetEnvironment(env map[string]string) {
+	fs.Metadata.Environment = env
+}
+
+// WriteToFile writes the fixture set as pretty JSON, the default format
+// callers used before WriteWithCodec's pluggable Codec support existed.
+func (fs *FixtureSet) WriteToFile(outputDir string) error {
+	return fs.WriteWithCodec(outputDir, jsonCodec{})
+}
+
 // Ptr is a helper to create a pointer to a string
 func Ptr(s string) *string {
 	return &s
@@ -108,12 +202,12 @@ func Ptr(s string) *string {
 
 // SpringInput represents input for spring physics tests
 type SpringInput struct {
-	Frequency      float64 `json:"frequency"`
-	Damping        float64 `json:"damping"`
-	CurrentPos     float64 `json:"current_pos"`
-	TargetPos      float64 `json:"target_pos"`
-	Velocity       float64 `json:"velocity"`
-	DeltaTime      float64 `json:"delta_time"`
+	Frequency  float64 `json:"frequency"`
+	Damping    float64 `json:"damping"`
+	CurrentPos float64 `json:"current_pos"`
+	TargetPos  float64 `json:"target_pos"`
+	Velocity   float64 `json:"velocity"`
+	DeltaTime  float64 `json:"delta_time"`
 }
 
 // SpringOutput represents output from spring physics tests
@@ -146,20 +240,20 @@ type ProjectileOutput struct {
 
 // StyleInput represents input for style rendering tests
 type StyleInput struct {
-	Foreground   *string `json:"foreground,omitempty"`
-	Background   *string `json:"background,omitempty"`
-	Bold         bool    `json:"bold"`
-	Italic       bool    `json:"italic"`
-	Underline    bool    `json:"underline"`
-	Strikethrough bool   `json:"strikethrough"`
-	Faint        bool    `json:"faint"`
-	Blink        bool    `json:"blink"`
-	Reverse      bool    `json:"reverse"`
-	Text         string  `json:"text"`
-	Width        int     `json:"width,omitempty"`
-	Height       int     `json:"height,omitempty"`
-	Padding      []int   `json:"padding,omitempty"`
-	Margin       []int   `json:"margin,omitempty"`
+	Foreground    *string `json:"foreground,omitempty"`
+	Background    *string `json:"background,omitempty"`
+	Bold          bool    `json:"bold"`
+	Italic        bool    `json:"italic"`
+	Underline     bool    `json:"underline"`
+	Strikethrough bool    `json:"strikethrough"`
+	Faint         bool    `json:"faint"`
+	Blink         bool    `json:"blink"`
+	Reverse       bool    `json:"reverse"`
+	Text          string  `json:"text"`
+	Width         int     `json:"width,omitempty"`
+	Height        int     `json:"height,omitempty"`
+	Padding       []int   `json:"padding,omitempty"`
+	Margin        []int   `json:"margin,omitempty"`
 }
 
 // StyleOutput represents output from style rendering tests
@@ -182,3 +276,54 @@ type BorderInput struct {
 type BorderOutput struct {
 	Rendered string `json:"rendered"`
 }
+
+// RenderMode mirrors glow's tty detection: it controls whether output is
+// styled as though writing to an interactive terminal, forced plain as
+// though piped to a file, or auto-detected the way the glow CLI does.
+type RenderMode string
+
+const (
+	RenderModeAuto  RenderMode = "auto"
+	RenderModeTTY   RenderMode = "tty"
+	RenderModeNoTTY RenderMode = "no-tty"
+)
+
+// GlowInput represents input for glow reader tests
+type GlowInput struct {
+	Markdown   string     `json:"markdown"`
+	Style      string     `json:"style"`
+	Width      *int       `json:"width,omitempty"`
+	Pager      bool       `json:"pager"`
+	RenderMode RenderMode `json:"render_mode,omitempty"`
+}
+
+// GlowOutput represents output from glow reader tests
+type GlowOutput struct {
+	Output string `json:"output"`
+	Error  bool   `json:"error"`
+}
+
+// InputSequenceInput represents a raw byte stream fed to the input
+// sequence driver, preserved verbatim (including any embedded NUL or
+// invalid UTF-8) so the Rust side replays the exact bytes a Go run saw.
+type InputSequenceInput struct {
+	Sequence string `json:"sequence"`
+}
+
+// InputSequenceStep is one event the driver produced while consuming an
+// InputSequenceInput, plus how many of the remaining bytes it consumed
+// to produce it (0 meaning the driver made no progress and had to skip a
+// byte as error recovery).
+type InputSequenceStep struct {
+	Kind     string `json:"kind"`
+	Consumed int    `json:"consumed"`
+	Skipped  bool   `json:"skipped"`
+}
+
+// InputSequenceOutput represents output from driving an InputSequenceInput
+// through the parser to exhaustion.
+type InputSequenceOutput struct {
+	Steps         []InputSequenceStep `json:"steps"`
+	TotalConsumed int                 `json:"total_consumed"`
+	FullyConsumed bool                `json:"fully_consumed"`
+}
@@ -0,0 +1,145 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// Codec encodes a FixtureSet into one on-disk format. Extension reports the
+// file extension (without the leading dot) WriteWithCodec should use.
+type Codec interface {
+	Extension() string
+	Encode(fs *FixtureSet) ([]byte, error)
+}
+
+var codecs = map[string]Codec{}
+
+// RegisterCodec makes a Codec available under name, so a capture binary's
+// -format flag can select it alongside the built-in json/yaml/msgpack/
+// cbor/jsonl codecs.
+func RegisterCodec(name string, c Codec) {
+	codecs[name] = c
+}
+
+// CodecByName looks up a registered codec by name, returning false if none
+// is registered under that name.
+func CodecByName(name string) (Codec, bool) {
+	c, ok := codecs[name]
+	return c, ok
+}
+
+func init() {
+	RegisterCodec("json", jsonCodec{})
+	RegisterCodec("yaml", yamlCodec{})
+	RegisterCodec("msgpack", msgpackCodec{})
+	RegisterCodec("cbor", cborCodec{})
+	RegisterCodec("jsonl", jsonlCodec{})
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Extension() string { return "json" }
+func (jsonCodec) Encode(fs *FixtureSet) ([]byte, error) {
+	return json.MarshalIndent(fs, "", "  ")
+}
+
+// yamlCodec is the human-diffable format: reviewers comparing a fixture
+// change in a PR can read it without the JSON indentation noise.
+type yamlCodec struct{}
+
+func (yamlCodec) Extension() string { return "yaml" }
+func (yamlCodec) Encode(fs *FixtureSet) ([]byte, error) {
+	return yaml.Marshal(fs)
+}
+
+// msgpackCodec is the compact binary format, for a Rust harness loading
+// thousands of fixtures without paying JSON's parsing overhead.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Extension() string { return "msgpack" }
+func (msgpackCodec) Encode(fs *FixtureSet) ([]byte, error) {
+	return msgpack.Marshal(fs)
+}
+
+type cborCodec struct{}
+
+func (cborCodec) Extension() string { return "cbor" }
+func (cborCodec) Encode(fs *FixtureSet) ([]byte, error) {
+	return cbor.Marshal(fs)
+}
+
+// jsonlCodec writes one JSON object per line -- a metadata line followed
+// by one line per TestFixture -- so a huge benchmark suite's fixtures can
+// be streamed or grepped without loading the whole file into memory.
+type jsonlCodec struct{}
+
+func (jsonlCodec) Extension() string { return "jsonl" }
+func (jsonlCodec) Encode(fs *FixtureSet) ([]byte, error) {
+	var buf bytes.Buffer
+
+	metaLine, err := json.Marshal(struct {
+		Type     string          `json:"type"`
+		Metadata FixtureMetadata `json:"metadata"`
+	}{"metadata", fs.Metadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata line: %w", err)
+	}
+	buf.Write(metaLine)
+	buf.WriteByte('\n')
+
+	for _, t := range fs.Tests {
+		line, err := json.Marshal(struct {
+			Type string `json:"type"`
+			TestFixture
+		}{"test", t})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal test %q: %w", t.Name, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteWithFormat looks up format (e.g. from a capture binary's -format
+// flag) via CodecByName and writes the fixture set with it, returning an
+// error if format isn't registered.
+func (fs *FixtureSet) WriteWithFormat(dir, format string) error {
+	c, ok := CodecByName(format)
+	if !ok {
+		return fmt.Errorf("unknown fixture format %q", format)
+	}
+	return fs.WriteWithCodec(dir, c)
+}
+
+// WriteWithCodec encodes the fixture set with c and writes it to
+// <dir>/<crate>.<c.Extension()>.
+func (fs *FixtureSet) WriteWithCodec(dir string, c Codec) error {
+	data, err := c.Encode(fs)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixtures: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := filepath.Join(dir, fs.Metadata.Crate+"."+c.Extension())
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture file: %w", err)
+	}
+
+	fmt.Printf("Wrote %d tests to %s\n", len(fs.Tests), filename)
+
+	if err := fs.WriteMemoManifest(dir); err != nil {
+		return fmt.Errorf("failed to write memo manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package capture
+
+// CommonMarkSpecInput is one example from the CommonMark spec's test
+// suite: a markdown snippet, the section of the spec it illustrates,
+// and its example number within that corpus.
+type CommonMarkSpecInput struct {
+	Markdown string `json:"markdown"`
+	Section  string `json:"section"`
+	Example  int    `json:"example"`
+}
+
+// CommonMarkSpecOutput is a CommonMark spec example's result: the
+// spec's own expected HTML, glamour's rendered terminal output (style
+// "notty"), that output with ANSI escapes stripped, and which
+// structural block types (headings, lists, code, etc.) were detected
+// on each side, so a Rust port can be scored section by section rather
+// than only pass/fail on exact bytes.
+type CommonMarkSpecOutput struct {
+	ExpectedHTML   string          `json:"expected_html"`
+	Rendered       string          `json:"rendered"`
+	PlainText      string          `json:"plain_text"`
+	StructuralDiff map[string]bool `json:"structural_diff"`
+}
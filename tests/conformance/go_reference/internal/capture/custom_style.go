@@ -0,0 +1,32 @@
+package capture
+
+// CustomStyleInput is the input to a custom-JSON-style test: the style
+// file name under testdata/styles and the document rendered with it.
+type CustomStyleInput struct {
+	StyleFile string `json:"style_file"`
+	Document  string `json:"document"`
+}
+
+// EffectiveStyle mirrors styleconfig.EffectiveStyle as a fixture
+// value: the fully merged style -- every field populated, with
+// defaults applied -- for the small subset of glamour's JSON style
+// schema these fixtures cover.
+type EffectiveStyle struct {
+	H1Color              string `json:"h1_color"`
+	CodeBlockTheme       string `json:"code_block_theme"`
+	TableCenterSeparator string `json:"table_center_separator"`
+	TableColumnSeparator string `json:"table_column_separator"`
+	TableRowSeparator    string `json:"table_row_separator"`
+	DocumentMargin       uint   `json:"document_margin"`
+	BlockQuoteIndent     uint   `json:"block_quote_indent"`
+	EmphItalic           bool   `json:"emph_italic"`
+}
+
+// CustomStyleOutput is a custom-JSON-style test's result: the fully
+// merged effective style and glamour's rendered output for that style
+// file, so a Rust port's own style loader can be checked for the same
+// default-merging semantics and the same rendered bytes.
+type CustomStyleOutput struct {
+	EffectiveStyle EffectiveStyle `json:"effective_style"`
+	Rendered       string         `json:"rendered"`
+}
@@ -0,0 +1,49 @@
+package capture
+
+// DraggedProjectileInput is the input to a dragged-projectile simulation
+// step: harmonica's own Projectile is pure gravity (see ProjectileInput),
+// so this adds a linear drag term, acceleration a = g - k*v, with k the
+// drag coefficient. k=0 must reduce exactly to the gravity-only case.
+type DraggedProjectileInput struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Z         float64 `json:"z"`
+	VelX      float64 `json:"vel_x"`
+	VelY      float64 `json:"vel_y"`
+	VelZ      float64 `json:"vel_z"`
+	Gravity   float64 `json:"gravity"`
+	Drag      float64 `json:"drag"`
+	DeltaTime float64 `json:"delta_time"`
+}
+
+// DraggedProjectileOutput is a dragged-projectile simulation step's
+// result.
+type DraggedProjectileOutput struct {
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Z    float64 `json:"z"`
+	VelX float64 `json:"vel_x"`
+	VelY float64 `json:"vel_y"`
+	VelZ float64 `json:"vel_z"`
+}
+
+// StepDraggedProjectile advances one frame of dt using semi-implicit
+// Euler integration, matching harmonica's own Projectile.Update style:
+// velocity is updated first, then position is advanced using the
+// already-updated velocity. Gravity pulls along -Y only; drag decelerates
+// every axis in proportion to its own velocity.
+func StepDraggedProjectile(in DraggedProjectileInput) DraggedProjectileOutput {
+	dt := in.DeltaTime
+	vx := in.VelX - in.Drag*in.VelX*dt
+	vy := in.VelY - in.Gravity*dt - in.Drag*in.VelY*dt
+	vz := in.VelZ - in.Drag*in.VelZ*dt
+
+	return DraggedProjectileOutput{
+		X:    in.X + vx*dt,
+		Y:    in.Y + vy*dt,
+		Z:    in.Z + vz*dt,
+		VelX: vx,
+		VelY: vy,
+		VelZ: vz,
+	}
+}
@@ -0,0 +1,117 @@
+package capture
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// FormStepResult captures a *huh.Form's observable state after one step of
+// a keystroke script. huh does not expose a public "focused field" accessor,
+// so View -- which includes huh's own focus styling -- is the only
+// byte-level signal a Rust port can compare focus against; Values is read
+// back by the keys the driver's fields were tagged with via Field.Key.
+type FormStepResult struct {
+	Step      string                 `json:"step"`
+	View      string                 `json:"view"`
+	Values    map[string]interface{} `json:"values"`
+	Completed bool                   `json:"completed"`
+	Aborted   bool                   `json:"aborted"`
+}
+
+// FormDriver steps a *huh.Form through a keystroke script one bubbletea
+// message at a time via Form.Update, recording a FormStepResult after each
+// step so a Rust port can be checked for byte-identical frames against the
+// same input stream, not just matching initial state.
+type FormDriver struct {
+	form *huh.Form
+	keys []string
+}
+
+// NewFormDriver wraps form for scripted stepping. keys are the Field.Key
+// names to read back into each FormStepResult.Values via form.Get.
+func NewFormDriver(form *huh.Form, keys ...string) *FormDriver {
+	return &FormDriver{form: form, keys: keys}
+}
+
+// Run initializes d's form and steps it through steps (key names like
+// "tab", "down", "enter", or single runes for literal character entry),
+// returning one FormStepResult for the initial state plus one per step.
+func (d *FormDriver) Run(steps []string) []FormStepResult {
+	results := make([]FormStepResult, 0, len(steps)+1)
+
+	d.absorb(d.form.Init())
+	results = append(results, d.snapshot("init"))
+
+	for _, step := range steps {
+		model, cmd := d.form.Update(keyMsgFor(step))
+		if f, ok := model.(*huh.Form); ok {
+			d.form = f
+		}
+		d.absorb(cmd)
+		results = append(results, d.snapshot(step))
+	}
+
+	return results
+}
+
+// absorb runs cmd (if any) and feeds the message it produces back into the
+// form, repeating until a Cmd returns nil -- matching how a real bubbletea
+// program drains the Cmd a field's Focus/Blur/validation returns before the
+// next keystroke is delivered.
+func (d *FormDriver) absorb(cmd tea.Cmd) {
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			return
+		}
+		model, next := d.form.Update(msg)
+		if f, ok := model.(*huh.Form); ok {
+			d.form = f
+		}
+		cmd = next
+	}
+}
+
+func (d *FormDriver) snapshot(step string) FormStepResult {
+	values := make(map[string]interface{}, len(d.keys))
+	for _, k := range d.keys {
+		values[k] = d.form.Get(k)
+	}
+	return FormStepResult{
+		Step:      step,
+		View:      d.form.View(),
+		Values:    values,
+		Completed: d.form.State == huh.StateCompleted,
+		Aborted:   d.form.State == huh.StateAborted,
+	}
+}
+
+// keyMsgFor converts a script token to the tea.KeyMsg a real keyboard would
+// send. Anything not recognized as a named control is treated as literal
+// rune input, e.g. "h" or "i" typed into a text field.
+func keyMsgFor(step string) tea.Msg {
+	switch step {
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(step)}
+	}
+}
@@ -0,0 +1,31 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteCorpus writes each of inputs as its own numbered JSON file under
+// <dir>/<crate>/corpus/<kind>/, so the Rust conformance harness can replay
+// the exact fuzz-generated inputs a Go run produced without re-deriving
+// them from the seed.
+func WriteCorpus[I any](dir, crate, kind string, inputs []I) error {
+	corpusDir := filepath.Join(dir, crate, "corpus", kind)
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		return fmt.Errorf("fuzz: creating corpus dir: %w", err)
+	}
+
+	for i, in := range inputs {
+		data, err := json.MarshalIndent(in, "", "  ")
+		if err != nil {
+			return fmt.Errorf("fuzz: marshaling corpus entry %d: %w", i, err)
+		}
+		name := fmt.Sprintf("%04d.json", i)
+		if err := os.WriteFile(filepath.Join(corpusDir, name), data, 0644); err != nil {
+			return fmt.Errorf("fuzz: writing corpus entry %s: %w", name, err)
+		}
+	}
+	return nil
+}
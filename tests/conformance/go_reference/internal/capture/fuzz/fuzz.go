@@ -0,0 +1,109 @@
+package fuzz
+
+import (
+	"fmt"
+	"math/rand"
+
+	"charmed_conformance/internal/capture"
+)
+
+// generatorVersion identifies this package's generation logic. Bump it
+// whenever Generate* changes shape or distribution, so a FixtureMetadata.Notes
+// recorded by an older version is recognizable as such.
+const generatorVersion = "1"
+
+func notesFor(seed int64) string {
+	return fmt.Sprintf("fuzz: seed=%d generator_version=%s", seed, generatorVersion)
+}
+
+// FuzzSpring appends count randomly generated spring_fuzz_* fixtures to fs,
+// tagged "fuzz", and writes their inputs as a replayable corpus under
+// <outputDir>/<fs.Metadata.Crate>/corpus/spring/.
+func FuzzSpring(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.SpringInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateSpringInput(r)
+		out := RunSpring(in)
+		fs.AddTestWithTags(fmt.Sprintf("spring_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "spring", inputs)
+}
+
+// FuzzProjectile is FuzzSpring for ProjectileInput, writing its corpus under
+// .../corpus/projectile/.
+func FuzzProjectile(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.ProjectileInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateProjectileInput(r)
+		out := RunProjectile(in)
+		fs.AddTestWithTags(fmt.Sprintf("projectile_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "projectile", inputs)
+}
+
+// FuzzStyle is FuzzSpring for StyleInput, writing its corpus under
+// .../corpus/style/.
+func FuzzStyle(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.StyleInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateStyleInput(r)
+		out := RunStyle(in)
+		fs.AddTestWithTags(fmt.Sprintf("style_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "style", inputs)
+}
+
+// FuzzBorder is FuzzSpring for BorderInput, writing its corpus under
+// .../corpus/border/.
+func FuzzBorder(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.BorderInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateBorderInput(r)
+		out := RunBorder(in)
+		fs.AddTestWithTags(fmt.Sprintf("border_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "border", inputs)
+}
+
+// FuzzGlow is FuzzSpring for GlowInput, writing its corpus under
+// .../corpus/glow/.
+func FuzzGlow(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.GlowInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateGlowInput(r)
+		out := RunGlow(in)
+		fs.AddTestWithTags(fmt.Sprintf("glow_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "glow", inputs)
+}
+
+// FuzzInputSequence is FuzzSpring for InputSequenceInput, writing its
+// corpus under .../corpus/input_sequence/ so a cargo-fuzz-style harness on
+// the Rust side can replay the exact byte streams a Go run generated.
+func FuzzInputSequence(fs *capture.FixtureSet, outputDir string, seed int64, count int) error {
+	r := rand.New(rand.NewSource(seed))
+	inputs := make([]capture.InputSequenceInput, 0, count)
+	for i := 0; i < count; i++ {
+		in := GenerateInputSequence(r)
+		out := RunInputSequence(in)
+		fs.AddTestWithTags(fmt.Sprintf("input_sequence_fuzz_%03d", i), in, out, []string{"fuzz"})
+		inputs = append(inputs, in)
+	}
+	fs.Metadata.Notes = capture.Ptr(notesFor(seed))
+	return WriteCorpus(outputDir, fs.Metadata.Crate, "input_sequence", inputs)
+}
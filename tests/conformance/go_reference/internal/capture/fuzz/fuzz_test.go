@@ -0,0 +1,204 @@
+package fuzz
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// TestQuickSpringStaysFinite checks, via testing/quick, that every
+// generated spring case produces a finite position and velocity -- the
+// property a Rust conformance run actually needs to hold for any fixture
+// it's handed, generated or hand-written.
+func TestQuickSpringStaysFinite(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateSpringInput(rand.New(rand.NewSource(seed)))
+		out := RunSpring(in)
+		return isFinite(out.NewPos) && isFinite(out.NewVelocity)
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestQuickProjectileStaysFinite is TestQuickSpringStaysFinite for
+// GenerateProjectileInput/RunProjectile.
+func TestQuickProjectileStaysFinite(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateProjectileInput(rand.New(rand.NewSource(seed)))
+		out := RunProjectile(in)
+		return isFinite(out.X) && isFinite(out.Y) && isFinite(out.Z) &&
+			isFinite(out.VelX) && isFinite(out.VelY) && isFinite(out.VelZ)
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestQuickStyleRendersText checks that a random StyleInput always renders
+// its input text into the output somewhere -- styling changes how text
+// looks, never whether it's present.
+func TestQuickStyleRendersText(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateStyleInput(rand.New(rand.NewSource(seed)))
+		out := RunStyle(in)
+		return in.Text == "" || stringsContainsRune(out.Rendered, in.Text)
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestQuickBorderRendersText is TestQuickStyleRendersText for
+// GenerateBorderInput/RunBorder.
+func TestQuickBorderRendersText(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateBorderInput(rand.New(rand.NewSource(seed)))
+		out := RunBorder(in)
+		return in.Text == "" || stringsContainsRune(out.Rendered, in.Text)
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestQuickGlowNeverPanics checks that rendering a random markdown document
+// through glamour always completes and reports its error state rather than
+// panicking -- the property the Rust port relies on to treat GlowOutput.Error
+// as the sole failure signal.
+func TestQuickGlowNeverPanics(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateGlowInput(rand.New(rand.NewSource(seed)))
+		out := RunGlow(in)
+		return out.Error || out.Output != "" || in.Markdown == ""
+	}
+	if err := quick.Check(prop, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestQuickInputSequenceFullyConsumed checks that driving any generated
+// InputSequenceInput through RunInputSequence always accounts for every
+// byte, whether by a recognized step or a skipped-byte recovery step --
+// the invariant the Rust replay harness needs to trust the corpus won't
+// hang its own driver loop.
+func TestQuickInputSequenceFullyConsumed(t *testing.T) {
+	prop := func(seed int64) bool {
+		in := GenerateInputSequence(rand.New(rand.NewSource(seed)))
+		out := RunInputSequence(in)
+		return out.FullyConsumed
+	}
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// stringsContainsRune reports whether needle's words still appear, in
+// order, inside haystack, ignoring whitespace differences -- lipgloss and
+// glamour expand tabs and wrap long lines, which reflows the whitespace
+// between words without dropping any of the words themselves, so a raw
+// substring match isn't the right invariant to check.
+func stringsContainsRune(haystack, needle string) bool {
+	return len(needle) == 0 || indexOf(normalizeWhitespace(haystack), normalizeWhitespace(needle)) >= 0
+}
+
+// normalizeWhitespace collapses every run of whitespace into a single
+// space and trims the ends.
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func indexOf(haystack, needle string) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if haystack[i:i+len(needle)] == needle {
+			return i
+		}
+	}
+	return -1
+}
+
+// FuzzSpringInput is the native Go fuzz entry point for spring physics: each
+// seed deterministically generates one SpringInput, so a failing seed
+// reproduces its counterexample.
+func FuzzSpringInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateSpringInput(rand.New(rand.NewSource(seed)))
+		out := RunSpring(in)
+		if !isFinite(out.NewPos) || !isFinite(out.NewVelocity) {
+			t.Fatalf("non-finite spring output for seed %d: input=%+v output=%+v", seed, in, out)
+		}
+	})
+}
+
+// FuzzProjectileInput is FuzzSpringInput for projectile physics.
+func FuzzProjectileInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateProjectileInput(rand.New(rand.NewSource(seed)))
+		out := RunProjectile(in)
+		if !isFinite(out.X) || !isFinite(out.Y) || !isFinite(out.Z) {
+			t.Fatalf("non-finite projectile output for seed %d: input=%+v output=%+v", seed, in, out)
+		}
+	})
+}
+
+// FuzzStyleInput is FuzzSpringInput for lipgloss style rendering.
+func FuzzStyleInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateStyleInput(rand.New(rand.NewSource(seed)))
+		out := RunStyle(in)
+		if in.Text != "" && !stringsContainsRune(out.Rendered, in.Text) {
+			t.Fatalf("rendered output dropped input text for seed %d: input=%+v output=%+v", seed, in, out)
+		}
+	})
+}
+
+// FuzzBorderInput is FuzzSpringInput for lipgloss border rendering.
+func FuzzBorderInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateBorderInput(rand.New(rand.NewSource(seed)))
+		out := RunBorder(in)
+		if in.Text != "" && !stringsContainsRune(out.Rendered, in.Text) {
+			t.Fatalf("bordered output dropped input text for seed %d: input=%+v output=%+v", seed, in, out)
+		}
+	})
+}
+
+// FuzzGlowInput is FuzzSpringInput for glamour markdown rendering.
+func FuzzGlowInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateGlowInput(rand.New(rand.NewSource(seed)))
+		out := RunGlow(in)
+		if !out.Error && out.Output == "" && in.Markdown != "" {
+			t.Fatalf("glow rendering produced neither output nor an error for seed %d: input=%+v", seed, in)
+		}
+	})
+}
+
+// FuzzInputSequenceInput is FuzzSpringInput for the input sequence driver.
+func FuzzInputSequenceInput(f *testing.F) {
+	f.Add(int64(1))
+	f.Add(int64(42))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		in := GenerateInputSequence(rand.New(rand.NewSource(seed)))
+		out := RunInputSequence(in)
+		if !out.FullyConsumed {
+			t.Fatalf("driver did not fully consume sequence for seed %d: input=%+v output=%+v", seed, in, out)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package fuzz
+
+import (
+	"math"
+
+	"charmed_conformance/internal/capture"
+	"charmed_conformance/internal/inputparser"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/harmonica"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RunSpring runs in through harmonica's real spring physics, the same way
+// cmd/harmonica's captureSpringTests does. Velocity is a plain argument to
+// Update, not separate state set after construction -- harmonica.Spring has
+// no SetVelocity.
+func RunSpring(in capture.SpringInput) capture.SpringOutput {
+	fps := int(math.Round(1.0 / in.DeltaTime))
+	spring := harmonica.NewSpring(harmonica.FPS(fps), in.Frequency, in.Damping)
+	pos, vel := spring.Update(in.CurrentPos, in.Velocity, in.TargetPos)
+	return capture.SpringOutput{NewPos: pos, NewVelocity: vel}
+}
+
+// RunProjectile runs in through harmonica's real projectile physics, the
+// same way cmd/harmonica's captureProjectileTests does. Velocity and
+// acceleration are seeded directly in NewProjectile -- harmonica.Projectile
+// has no SetVelocity either.
+func RunProjectile(in capture.ProjectileInput) capture.ProjectileOutput {
+	fps := int(math.Round(1.0 / in.DeltaTime))
+	proj := harmonica.NewProjectile(
+		harmonica.FPS(fps),
+		harmonica.Point{X: in.X, Y: in.Y, Z: in.Z},
+		harmonica.Vector{X: in.VelX, Y: in.VelY, Z: in.VelZ},
+		harmonica.Vector{X: 0, Y: in.Gravity, Z: 0},
+	)
+	proj.Update()
+	pos := proj.Position()
+	vel := proj.Velocity()
+	return capture.ProjectileOutput{
+		X: pos.X, Y: pos.Y, Z: pos.Z,
+		VelX: vel.X, VelY: vel.Y, VelZ: vel.Z,
+	}
+}
+
+// RunStyle runs in through a real lipgloss.Style, the same way
+// cmd/lipgloss's captureBasicStyleTests/captureColorTests do.
+func RunStyle(in capture.StyleInput) capture.StyleOutput {
+	style := lipgloss.NewStyle()
+	if in.Foreground != nil {
+		style = style.Foreground(lipgloss.Color(*in.Foreground))
+	}
+	if in.Background != nil {
+		style = style.Background(lipgloss.Color(*in.Background))
+	}
+	style = style.
+		Bold(in.Bold).
+		Italic(in.Italic).
+		Underline(in.Underline).
+		Strikethrough(in.Strikethrough).
+		Faint(in.Faint).
+		Blink(in.Blink).
+		Reverse(in.Reverse)
+	if in.Width > 0 {
+		style = style.Width(in.Width)
+	}
+	if in.Height > 0 {
+		style = style.Height(in.Height)
+	}
+	if len(in.Padding) > 0 {
+		style = style.Padding(in.Padding...)
+	}
+	if len(in.Margin) > 0 {
+		style = style.Margin(in.Margin...)
+	}
+
+	rendered := style.Render(in.Text)
+	return capture.StyleOutput{
+		Rendered: rendered,
+		Width:    lipgloss.Width(rendered),
+		Height:   lipgloss.Height(rendered),
+	}
+}
+
+func borderStyleFor(name string) lipgloss.Border {
+	switch name {
+	case "rounded":
+		return lipgloss.RoundedBorder()
+	case "double":
+		return lipgloss.DoubleBorder()
+	case "thick":
+		return lipgloss.ThickBorder()
+	case "block":
+		return lipgloss.BlockBorder()
+	case "hidden":
+		return lipgloss.HiddenBorder()
+	default:
+		return lipgloss.NormalBorder()
+	}
+}
+
+// RunBorder runs in through a real lipgloss bordered style, the same way
+// cmd/lipgloss's captureBorderTests does.
+func RunBorder(in capture.BorderInput) capture.BorderOutput {
+	style := lipgloss.NewStyle().Border(borderStyleFor(in.BorderType))
+	if in.Foreground != nil {
+		style = style.Foreground(lipgloss.Color(*in.Foreground))
+	}
+	if in.Background != nil {
+		style = style.Background(lipgloss.Color(*in.Background))
+	}
+	if in.Width > 0 {
+		style = style.Width(in.Width)
+	}
+	return capture.BorderOutput{Rendered: style.Render(in.Text)}
+}
+
+// RunGlow runs in through a real glamour.TermRenderer, the same way
+// cmd/glow's buildRenderer/captureReaderTests do.
+func RunGlow(in capture.GlowInput) capture.GlowOutput {
+	var opts []glamour.TermRendererOption
+	switch in.RenderMode {
+	case capture.RenderModeAuto:
+		opts = append(opts, glamour.WithAutoStyle())
+	case capture.RenderModeNoTTY:
+		opts = append(opts, glamour.WithStandardStyle("notty"))
+	default:
+		opts = append(opts, glamour.WithStandardStyle(in.Style))
+	}
+
+	w := 80
+	if in.Width != nil {
+		w = *in.Width
+	}
+	opts = append(opts, glamour.WithWordWrap(w))
+
+	renderer, err := glamour.NewTermRenderer(opts...)
+	if err != nil {
+		return capture.GlowOutput{Error: true}
+	}
+	out, err := renderer.Render(in.Markdown)
+	return capture.GlowOutput{Output: out, Error: err != nil}
+}
+
+// RunInputSequence drives in.Sequence through inputparser.Parse to
+// exhaustion, recording every event produced. When Parse makes no
+// progress (an unrecognized or irrecoverably partial prefix), one byte is
+// skipped and marked Skipped, mirroring the error-recovery a real driver
+// needs to avoid stalling on a sequence it can't fully interpret.
+func RunInputSequence(in capture.InputSequenceInput) capture.InputSequenceOutput {
+	var out capture.InputSequenceOutput
+	remaining := in.Sequence
+
+	for len(remaining) > 0 {
+		ev, consumed := inputparser.Parse(remaining)
+		if consumed <= 0 {
+			out.Steps = append(out.Steps, capture.InputSequenceStep{
+				Kind:    string(ev.Kind),
+				Skipped: true,
+			})
+			remaining = remaining[1:]
+			out.TotalConsumed++
+			continue
+		}
+		out.Steps = append(out.Steps, capture.InputSequenceStep{
+			Kind:     string(ev.Kind),
+			Consumed: consumed,
+		})
+		remaining = remaining[consumed:]
+		out.TotalConsumed += consumed
+	}
+
+	out.FullyConsumed = out.TotalConsumed == len(in.Sequence)
+	return out
+}
@@ -0,0 +1,42 @@
+package fuzz
+
+// ShrinkFloat64 binary-searches the interval between a known-good baseline
+// and a failing value for the smallest-magnitude value on which fails still
+// reports a failure, so a Rust conformance run that rejects a fuzz fixture
+// can request a minimised counterexample instead of the original
+// (potentially large, hard-to-read) one. It complements Go's native fuzz
+// corpus minimization for callers driving a shrink step directly, outside
+// of `go test -fuzz`.
+func ShrinkFloat64(baseline, failing float64, fails func(float64) bool, steps int) float64 {
+	lo, hi := baseline, failing
+	for i := 0; i < steps; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if fails(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+// ShrinkInt is ShrinkFloat64 for integer-valued inputs (e.g. a StyleInput
+// Width or a glow GlowInput Width), stopping once lo and hi converge.
+func ShrinkInt(baseline, failing int, fails func(int) bool, steps int) int {
+	lo, hi := baseline, failing
+	for i := 0; i < steps; i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if fails(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
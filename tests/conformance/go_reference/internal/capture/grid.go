@@ -0,0 +1,15 @@
+package capture
+
+// GridInput is the input to a pooltable grid test: a ragged grid of
+// cells (rows may have different cell counts) plus the Dimension
+// ("priority_list" or "priority_column") that decides how column
+// widths are resolved across rows. See internal/pooltable.
+type GridInput struct {
+	Rows      [][]string `json:"rows"`
+	Dimension string     `json:"dimension"`
+}
+
+// GridOutput is a pooltable grid test's result: the rendered grid.
+type GridOutput struct {
+	Rendered string `json:"rendered"`
+}
@@ -0,0 +1,34 @@
+package capture
+
+import "math"
+
+// KineticScrollFrame is one simulated frame of a kinetic scroll: its
+// position, velocity, and which sub-simulation produced it ("friction",
+// "spring_leading", or "spring_trailing").
+type KineticScrollFrame struct {
+	X     float64 `json:"x"`
+	V     float64 `json:"v"`
+	Phase string  `json:"phase"`
+}
+
+// KineticScrollOutput is a kinetic scroll simulation's full per-frame
+// trace, plus whether it settled (rather than hitting its frame budget
+// still moving).
+type KineticScrollOutput struct {
+	Frames  []KineticScrollFrame `json:"frames"`
+	Settled bool                 `json:"settled"`
+}
+
+// FrictionPosition evaluates the closed-form exponential-decay friction
+// curve kinetic scrolling uses (the same shape as iOS/Android
+// deceleration) at elapsed time t since the flick that produced velocity
+// v0 at position x0, given a per-unit-time drag coefficient in (0,1).
+// Unlike AnalyticSpring's recurrence, this is a pure function of elapsed
+// time: repeated calls with the same (x0, v0, drag) and increasing t need
+// no running state between them.
+func FrictionPosition(x0, v0, drag, t float64) (x, v float64) {
+	decay := math.Pow(drag, t)
+	v = v0 * decay
+	x = x0 + v0*(decay-1)/math.Log(drag)
+	return x, v
+}
@@ -0,0 +1,113 @@
+package capture
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// builtinLevel is one of charmbracelet/log's five canonical levels,
+// duplicated here as plain data (rather than importing the library) so
+// this package's level-naming model has no dependency on any one logging
+// library's types. The values match both charmbracelet/log's Level
+// constants and log/slog's Debug/Info/Warn/Error scheme, which is why
+// custom levels registered in between (e.g. NOTICE=2) interoperate with
+// both.
+var builtinLevels = []struct {
+	Name  string
+	Value int
+}{
+	{"DEBUG", -4},
+	{"INFO", 0},
+	{"WARN", 4},
+	{"ERROR", 8},
+	{"FATAL", 12},
+}
+
+var (
+	customLevelsMu sync.Mutex
+	customLevels   = map[int]string{}
+)
+
+// RegisterLevel names a custom integer level, e.g.
+// RegisterLevel("TRACE", -8), the capability charmbracelet/log itself
+// doesn't expose (its five levels are fixed). A registration for a value
+// that already has a builtin name (e.g. 12, FATAL's value) shadows it:
+// LevelName and LevelValue prefer the custom registry over the builtin
+// table.
+func RegisterLevel(name string, value int) {
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	customLevels[value] = name
+}
+
+// LevelName resolves value to a display name: a name registered via
+// RegisterLevel, one of the five builtin names, or -- for any other value
+// -- the nearest builtin level at or below it plus its signed offset
+// (e.g. "INFO+2", "DEBUG-4", "FATAL+4"), the same fallback algorithm
+// log/slog's Level.String() uses for its own four-level scheme.
+func LevelName(value int) string {
+	customLevelsMu.Lock()
+	name, ok := customLevels[value]
+	customLevelsMu.Unlock()
+	if ok {
+		return name
+	}
+
+	best := builtinLevels[0]
+	for _, lvl := range builtinLevels {
+		if lvl.Value <= value {
+			best = lvl
+		}
+	}
+	if diff := value - best.Value; diff != 0 {
+		return fmt.Sprintf("%s%+d", best.Name, diff)
+	}
+	return best.Name
+}
+
+// LevelValue looks up a name registered via RegisterLevel (or one of the
+// five builtin names) and returns its value, case-insensitively like
+// charmbracelet/log's own ParseLevel. It reports false for a name that
+// matches neither registry.
+func LevelValue(name string) (int, bool) {
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+
+	for value, n := range customLevels {
+		if strings.EqualFold(n, name) {
+			return value, true
+		}
+	}
+	for _, lvl := range builtinLevels {
+		if strings.EqualFold(lvl.Name, name) {
+			return lvl.Value, true
+		}
+	}
+	return 0, false
+}
+
+// RegisteredLevels returns every custom level RegisterLevel has recorded
+// so far, sorted by value, for a capture program that wants to iterate
+// the full registry rather than its own hardcoded list of names.
+func RegisteredLevels() []struct {
+	Name  string
+	Value int
+} {
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+
+	out := make([]struct {
+		Name  string
+		Value int
+	}, 0, len(customLevels))
+	for value, name := range customLevels {
+		out = append(out, struct {
+			Name  string
+			Value int
+		}{name, value})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Value < out[j].Value })
+	return out
+}
@@ -0,0 +1,137 @@
+package capture
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// MemoStore is a content-addressable, singleflight-protected cache keyed
+// by a canonical hash of a fixture's Input. Two goroutines requesting the
+// same key concurrently share one computation instead of racing to
+// produce it twice; a later call with a key already resolved gets the
+// cached result without recomputing it at all.
+type MemoStore struct {
+	mu       sync.Mutex
+	inFlight map[string]*memoCall
+	results  map[string]memoResult
+	names    map[string][]string // hash -> fixture names that used it
+}
+
+type memoCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+type memoResult struct {
+	value interface{}
+	err   error
+}
+
+// NewMemoStore creates an empty MemoStore.
+func NewMemoStore() *MemoStore {
+	return &MemoStore{
+		inFlight: make(map[string]*memoCall),
+		results:  make(map[string]memoResult),
+		names:    make(map[string][]string),
+	}
+}
+
+// HashInput computes the canonical content hash GetOrCreate/AddComputed
+// key on: a stable JSON encoding of input, hashed with SHA-256.
+func HashInput(input interface{}) (string, error) {
+	canon, err := canonicalJSON(input)
+	if err != nil {
+		return "", fmt.Errorf("capture: canonicalizing input: %w", err)
+	}
+	sum := sha256.Sum256(canon)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON round-trips v through a generic interface{} so structurally
+// identical inputs hash the same regardless of concrete Go type (a struct
+// and the map[string]interface{} it would decode into produce identical
+// bytes), and so map keys come out in encoding/json's sorted order.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// GetOrCreate runs produce exactly once per distinct key: the first
+// caller for a key runs produce while concurrent and subsequent callers
+// for that key block on (and then reuse) its result.
+func (m *MemoStore) GetOrCreate(key string, produce func() (interface{}, error)) (interface{}, error) {
+	m.mu.Lock()
+	if r, ok := m.results[key]; ok {
+		m.mu.Unlock()
+		return r.value, r.err
+	}
+	if call, ok := m.inFlight[key]; ok {
+		m.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+
+	call := &memoCall{}
+	call.wg.Add(1)
+	m.inFlight[key] = call
+	m.mu.Unlock()
+
+	call.value, call.err = produce()
+	call.wg.Done()
+
+	m.mu.Lock()
+	delete(m.inFlight, key)
+	m.results[key] = memoResult{value: call.value, err: call.err}
+	m.mu.Unlock()
+
+	return call.value, call.err
+}
+
+// recordName tracks that fixture name's input hashed to key, for
+// WriteManifest.
+func (m *MemoStore) recordName(key, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.names[key] = append(m.names[key], name)
+}
+
+// WriteManifest writes the hash -> fixture names mapping to
+// <dir>/<crate>.memo-manifest.json, so a later incremental run can see
+// which fixtures shared a computation without re-deriving it.
+func (m *MemoStore) WriteManifest(dir, crate string) error {
+	m.mu.Lock()
+	manifest := make(map[string][]string, len(m.names))
+	for hash, names := range m.names {
+		sorted := append([]string(nil), names...)
+		sort.Strings(sorted)
+		manifest[hash] = sorted
+	}
+	m.mu.Unlock()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("capture: marshaling memo manifest: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("capture: creating output directory: %w", err)
+	}
+	path := filepath.Join(dir, crate+".memo-manifest.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("capture: writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+	"gopkg.in/yaml.v3"
+)
+
+// NumberHint tags a numeric fixture field as an integer or a float so it
+// round-trips identically across codecs. A bare interface{} holding an int
+// encodes fine as JSON, but YAML and CBOR decode generic JSON numbers as
+// float64, so a fixture built by decoding one codec and re-encoding it in
+// another would otherwise turn "width": 80 into "width": 80.0. Fields that
+// need that guarantee should use NumberHint instead of a raw int/float64.
+type NumberHint struct {
+	IsInt bool
+	Int   int64
+	Float float64
+}
+
+// IntHint creates an integer-tagged NumberHint.
+func IntHint(i int64) NumberHint {
+	return NumberHint{IsInt: true, Int: i}
+}
+
+// FloatHint creates a float-tagged NumberHint.
+func FloatHint(f float64) NumberHint {
+	return NumberHint{Float: f}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NumberHint) MarshalJSON() ([]byte, error) {
+	if n.IsInt {
+		return json.Marshal(n.Int)
+	}
+	return json.Marshal(n.Float)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, preserving whether the source
+// literal had a decimal point or exponent.
+func (n *NumberHint) UnmarshalJSON(data []byte) error {
+	if !bytes.ContainsAny(data, ".eE") {
+		var i int64
+		if err := json.Unmarshal(data, &i); err == nil {
+			*n = NumberHint{IsInt: true, Int: i}
+			return nil
+		}
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("NumberHint: %w", err)
+	}
+	*n = NumberHint{Float: f}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (n NumberHint) MarshalYAML() (interface{}, error) {
+	if n.IsInt {
+		return n.Int, nil
+	}
+	return n.Float, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, trusting the node's own
+// scalar tag (!!int vs. !!float) rather than re-parsing the literal text.
+func (n *NumberHint) UnmarshalYAML(value *yaml.Node) error {
+	if value.Tag == "!!int" {
+		i, err := strconv.ParseInt(value.Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("NumberHint: %w", err)
+		}
+		*n = NumberHint{IsInt: true, Int: i}
+		return nil
+	}
+	var f float64
+	if err := value.Decode(&f); err != nil {
+		return fmt.Errorf("NumberHint: %w", err)
+	}
+	*n = NumberHint{Float: f}
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (n NumberHint) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if n.IsInt {
+		return enc.EncodeInt64(n.Int)
+	}
+	return enc.EncodeFloat64(n.Float)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder, using the wire type the
+// encoder chose rather than guessing from a decoded interface{}.
+func (n *NumberHint) DecodeMsgpack(dec *msgpack.Decoder) error {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return fmt.Errorf("NumberHint: %w", err)
+	}
+	if code == msgpcode.Float || code == msgpcode.Double {
+		f, err := dec.DecodeFloat64()
+		if err != nil {
+			return fmt.Errorf("NumberHint: %w", err)
+		}
+		*n = NumberHint{Float: f}
+		return nil
+	}
+	i, err := dec.DecodeInt64()
+	if err != nil {
+		return fmt.Errorf("NumberHint: %w", err)
+	}
+	*n = NumberHint{IsInt: true, Int: i}
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler. CBOR's own major types already
+// distinguish integers from floats, so this just picks the right literal.
+func (n NumberHint) MarshalCBOR() ([]byte, error) {
+	if n.IsInt {
+		return cbor.Marshal(n.Int)
+	}
+	return cbor.Marshal(n.Float)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler, trying the integer major type
+// first so a CBOR-encoded int isn't silently widened into a float64.
+func (n *NumberHint) UnmarshalCBOR(data []byte) error {
+	var i int64
+	if err := cbor.Unmarshal(data, &i); err == nil {
+		*n = NumberHint{IsInt: true, Int: i}
+		return nil
+	}
+	var f float64
+	if err := cbor.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("NumberHint: %w", err)
+	}
+	*n = NumberHint{Float: f}
+	return nil
+}
@@ -0,0 +1,37 @@
+package capture
+
+// SpringSleepInput is the input to a sleep/rest-state detection run: a
+// spring is simulated step by step until it is considered "at rest", or
+// until MaxFrames elapses without reaching rest. A spring is at rest once
+// its squared offset from target and squared velocity both drop below
+// their respective thresholds, rather than by tick count or an equality
+// check against zero.
+type SpringSleepInput struct {
+	Frequency         float64 `json:"frequency"`
+	Damping           float64 `json:"damping"`
+	CurrentPos        float64 `json:"current_pos"`
+	TargetPos         float64 `json:"target_pos"`
+	Velocity          float64 `json:"velocity"`
+	DeltaTime         float64 `json:"delta_time"`
+	OffsetThreshold   float64 `json:"offset_threshold"`
+	VelocityThreshold float64 `json:"velocity_threshold"`
+	MaxFrames         int     `json:"max_frames"`
+}
+
+// SpringSleepFrame is one simulated frame of a sleep/rest-state run.
+type SpringSleepFrame struct {
+	Pos float64 `json:"pos"`
+	Vel float64 `json:"vel"`
+}
+
+// SpringSleepOutput is a sleep/rest-state detection run's result. Settled
+// is false when the spring never satisfies the rest predicate within
+// MaxFrames, in which case SleepFrame is MaxFrames and (FinalPos,
+// FinalVelocity) are the values at that frame, not a true rest state.
+type SpringSleepOutput struct {
+	Settled       bool               `json:"settled"`
+	SleepFrame    int                `json:"sleep_frame"`
+	FinalPos      float64            `json:"final_pos"`
+	FinalVelocity float64            `json:"final_velocity"`
+	Trajectory    []SpringSleepFrame `json:"trajectory"`
+}
@@ -0,0 +1,36 @@
+package capture
+
+// StyleSerializationInput is the input to a style serialization
+// round-trip test: a lipgloss.Style's attributes, serialized to the
+// knz/lipgloss-convert text format (semicolon-separated
+// "property: value;" pairs) and parsed back, to give the Rust port a
+// golden corpus for an equivalent Style::to_string()/Style::from_str().
+type StyleSerializationInput struct {
+	Text             string  `json:"text"`
+	Bold             bool    `json:"bold"`
+	Italic           bool    `json:"italic"`
+	Underline        bool    `json:"underline"`
+	Strikethrough    bool    `json:"strikethrough"`
+	Faint            bool    `json:"faint"`
+	Blink            bool    `json:"blink"`
+	Reverse          bool    `json:"reverse"`
+	Foreground       *string `json:"foreground,omitempty"`
+	Background       *string `json:"background,omitempty"`
+	Padding          []int   `json:"padding,omitempty"`
+	Margin           []int   `json:"margin,omitempty"`
+	Width            int     `json:"width,omitempty"`
+	Height           int     `json:"height,omitempty"`
+	AlignHorizontal  string  `json:"align_horizontal,omitempty"`
+	AlignVertical    string  `json:"align_vertical,omitempty"`
+	BorderStyle      string  `json:"border_style,omitempty"`
+	BorderForeground *string `json:"border_foreground,omitempty"`
+	BorderBackground *string `json:"border_background,omitempty"`
+}
+
+// StyleSerializationOutput is a style serialization round-trip test's
+// result: the style's serialized text form, and the text rendered by
+// re-parsing that form back into a style.
+type StyleSerializationOutput struct {
+	Serialized        string `json:"serialized"`
+	RoundTripRendered string `json:"round_trip_rendered"`
+}
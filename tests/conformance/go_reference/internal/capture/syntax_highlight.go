@@ -0,0 +1,32 @@
+package capture
+
+// ColorSpan describes one contiguous, same-styled run within a
+// SyntaxHighlightOutput.Rendered string: its byte offset, byte length
+// (including any SGR escape sequences), and the SGR parameter string
+// applied (empty for an unstyled run).
+type ColorSpan struct {
+	Start  int    `json:"start"`
+	Length int    `json:"length"`
+	SGR    string `json:"sgr"`
+}
+
+// SyntaxHighlightInput is the input to a syntax-highlighting test: a
+// fenced code block's requested language (which may be an alias, e.g.
+// "golang" or "js"), the canonical language it resolved to, the chroma
+// style applied to its tokens, and the glamour style preset the block
+// was rendered under (which decides whether color is emitted at all).
+type SyntaxHighlightInput struct {
+	RequestedLanguage string `json:"requested_language"`
+	Language          string `json:"language"`
+	ChromaStyle       string `json:"chroma_style"`
+	GlamourStyle      string `json:"glamour_style"`
+}
+
+// SyntaxHighlightOutput is a syntax-highlighting test's result: the
+// rendered ANSI string and its token-level color spans, so a Rust port
+// can be checked against the same token classification and palette
+// rather than only the same final string.
+type SyntaxHighlightOutput struct {
+	Rendered   string      `json:"rendered"`
+	ColorSpans []ColorSpan `json:"color_spans"`
+}
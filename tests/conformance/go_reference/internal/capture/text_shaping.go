@@ -0,0 +1,28 @@
+package capture
+
+// TextShapingInput is the input to a text-shaping test: rendering text
+// that exercises tab expansion, reversed text under a known background,
+// East-Asian wide runes/combining marks/zero-width joiners inside a
+// padded, bordered, or aligned style, or trailing-whitespace padding
+// under a background color.
+type TextShapingInput struct {
+	Text              string  `json:"text"`
+	TabWidth          *int    `json:"tab_width,omitempty"`
+	Reverse           bool    `json:"reverse,omitempty"`
+	HasDarkBackground *bool   `json:"has_dark_background,omitempty"`
+	Background        *string `json:"background,omitempty"`
+	Width             int     `json:"width,omitempty"`
+	Padding           []int   `json:"padding,omitempty"`
+	BorderStyle       string  `json:"border_style,omitempty"`
+	AlignHorizontal   string  `json:"align_horizontal,omitempty"`
+}
+
+// TextShapingOutput is a text-shaping test's result: the rendered bytes
+// and the rendered block's visible column width (lipgloss.Width, which
+// accounts for East-Asian wide runes and combining marks the way
+// go-runewidth does, ignoring ANSI escapes).
+type TextShapingOutput struct {
+	Rendered     string `json:"rendered"`
+	VisibleWidth int    `json:"visible_width"`
+	Height       int    `json:"height"`
+}
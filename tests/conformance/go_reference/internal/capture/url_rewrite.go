@@ -0,0 +1,29 @@
+package capture
+
+// URLRewriteRule mirrors urlrewrite.Rule as a declarative JSON value:
+// one URL rewrite rule, keyed by the markdown construct it applies to.
+type URLRewriteRule struct {
+	Kind     string `json:"kind"`
+	Match    string `json:"match"`
+	Template string `json:"template"`
+}
+
+// URLRewriteInput is the input to a URL-rewriting test: the markdown
+// as authored, the rule that was applied to its image/link/autolink
+// URLs, and the BaseURL (if any) relative URLs were resolved against
+// first.
+type URLRewriteInput struct {
+	OriginalMarkdown string         `json:"original_markdown"`
+	Rule             URLRewriteRule `json:"rule"`
+	BaseURL          *string        `json:"base_url,omitempty"`
+}
+
+// URLRewriteOutput is a URL-rewriting test's result: the markdown
+// after rewriting, and glamour's rendered output for both the
+// original and rewritten markdown, so a Rust port's URLRewriter
+// option can be checked for byte-for-byte parity on either side.
+type URLRewriteOutput struct {
+	RewrittenMarkdown string `json:"rewritten_markdown"`
+	OriginalRendered  string `json:"original_rendered"`
+	RewrittenRendered string `json:"rewritten_rendered"`
+}
@@ -0,0 +1,56 @@
+//go:build darwin
+
+package filesystems
+
+import "syscall"
+
+// DarwinEnumerator lists mounts via syscall.Getfsstat, the stdlib wrapper
+// around BSD's getmntinfo(3).
+type DarwinEnumerator struct{}
+
+// Enumerate implements Enumerator.
+func (DarwinEnumerator) Enumerate() ([]Mount, error) {
+	n, err := syscall.Getfsstat(nil, syscall.MNT_NOWAIT)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]syscall.Statfs_t, n)
+	if _, err := syscall.Getfsstat(stats, syscall.MNT_NOWAIT); err != nil {
+		return nil, err
+	}
+
+	mounts := make([]Mount, 0, len(stats))
+	for _, stat := range stats {
+		blockSize := uint64(stat.Bsize)
+		total := stat.Blocks * blockSize
+		free := stat.Bfree * blockSize
+		avail := stat.Bavail * blockSize
+		used := total - free
+
+		var usePercent float64
+		if total > 0 {
+			usePercent = float64(used) / float64(total) * 100
+		}
+
+		mounts = append(mounts, Mount{
+			Device:     cString(stat.Mntfromname[:]),
+			Mountpoint: cString(stat.Mntonname[:]),
+			FSType:     cString(stat.Fstypename[:]),
+			SizeBytes:  total,
+			UsedBytes:  used,
+			AvailBytes: avail,
+			UsePercent: usePercent,
+		})
+	}
+	return mounts, nil
+}
+
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
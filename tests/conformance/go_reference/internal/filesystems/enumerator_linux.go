@@ -0,0 +1,65 @@
+//go:build linux
+
+package filesystems
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// LinuxEnumerator lists mounts by parsing /proc/mounts and statfs-ing
+// each mountpoint for size/used/available, mirroring what getmntinfo
+// reports on macOS.
+type LinuxEnumerator struct{}
+
+// Enumerate implements Enumerator.
+func (LinuxEnumerator) Enumerate() ([]Mount, error) {
+	f, err := os.Open("/proc/mounts")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []Mount
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		device, mountpoint, fsType := fields[0], fields[1], fields[2]
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(mountpoint, &stat); err != nil {
+			mounts = append(mounts, Mount{Device: device, Mountpoint: mountpoint, FSType: fsType})
+			continue
+		}
+
+		blockSize := uint64(stat.Bsize)
+		total := stat.Blocks * blockSize
+		free := stat.Bfree * blockSize
+		avail := stat.Bavail * blockSize
+		used := total - free
+
+		var usePercent float64
+		if total > 0 {
+			usePercent = float64(used) / float64(total) * 100
+		}
+
+		mounts = append(mounts, Mount{
+			Device:     device,
+			Mountpoint: mountpoint,
+			FSType:     fsType,
+			SizeBytes:  total,
+			UsedBytes:  used,
+			AvailBytes: avail,
+			UsePercent: usePercent,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mounts, nil
+}
@@ -0,0 +1,84 @@
+//go:build windows
+
+package filesystems
+
+import (
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32                 = syscall.NewLazyDLL("kernel32.dll")
+	procGetLogicalDriveStringsW = modkernel32.NewProc("GetLogicalDriveStringsW")
+	procGetDiskFreeSpaceExW     = modkernel32.NewProc("GetDiskFreeSpaceExW")
+	procGetVolumeInformationW   = modkernel32.NewProc("GetVolumeInformationW")
+)
+
+// WindowsEnumerator lists mounts via GetLogicalDriveStrings plus
+// GetDiskFreeSpaceEx and GetVolumeInformation per drive.
+type WindowsEnumerator struct{}
+
+// Enumerate implements Enumerator.
+func (WindowsEnumerator) Enumerate() ([]Mount, error) {
+	buf := make([]uint16, 254)
+	n, _, err := procGetLogicalDriveStringsW.Call(
+		uintptr(len(buf)),
+		uintptr(unsafe.Pointer(&buf[0])),
+	)
+	if n == 0 {
+		return nil, err
+	}
+
+	var mounts []Mount
+	for _, drive := range splitDriveStrings(buf[:n]) {
+		drivePtr, _ := syscall.UTF16PtrFromString(drive)
+
+		var freeAvail, total, free uint64
+		procGetDiskFreeSpaceExW.Call(
+			uintptr(unsafe.Pointer(drivePtr)),
+			uintptr(unsafe.Pointer(&freeAvail)),
+			uintptr(unsafe.Pointer(&total)),
+			uintptr(unsafe.Pointer(&free)),
+		)
+
+		fsNameBuf := make([]uint16, 260)
+		procGetVolumeInformationW.Call(
+			uintptr(unsafe.Pointer(drivePtr)),
+			0, 0, 0, 0, 0,
+			uintptr(unsafe.Pointer(&fsNameBuf[0])),
+			uintptr(len(fsNameBuf)),
+		)
+
+		used := total - free
+		var usePercent float64
+		if total > 0 {
+			usePercent = float64(used) / float64(total) * 100
+		}
+
+		mounts = append(mounts, Mount{
+			Device:     drive,
+			Mountpoint: drive,
+			FSType:     syscall.UTF16ToString(fsNameBuf),
+			SizeBytes:  total,
+			UsedBytes:  used,
+			AvailBytes: freeAvail,
+			UsePercent: usePercent,
+		})
+	}
+	return mounts, nil
+}
+
+// splitDriveStrings splits the NUL-delimited, double-NUL-terminated
+// buffer GetLogicalDriveStringsW fills in into individual drive strings
+// (e.g. "C:\\").
+func splitDriveStrings(buf []uint16) []string {
+	s := syscall.UTF16ToString(buf)
+	var drives []string
+	for _, part := range strings.Split(s, "\x00") {
+		if part != "" {
+			drives = append(drives, part)
+		}
+	}
+	return drives
+}
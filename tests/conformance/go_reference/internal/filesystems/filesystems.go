@@ -0,0 +1,125 @@
+// Package filesystems lists mounted filesystems -- device, mountpoint, fs
+// type, size, used, available, and use% -- similar to broot's :fs state,
+// and lets a filepicker jump its current directory to a selected
+// mountpoint. filepicker.Model has no such browser today, so this stands
+// in for it: platform enumerators live in build-tagged files, and the
+// Model itself depends only on the injectable Enumerator interface so
+// tests stay hermetic across platforms.
+package filesystems
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Mount describes a single mounted filesystem.
+type Mount struct {
+	Device     string
+	Mountpoint string
+	FSType     string
+	SizeBytes  uint64
+	UsedBytes  uint64
+	AvailBytes uint64
+	UsePercent float64
+}
+
+// Enumerator lists the currently mounted filesystems. Production code
+// uses the platform-specific enumerator for the running GOOS; tests
+// inject a stub so enumeration is hermetic.
+type Enumerator interface {
+	Enumerate() ([]Mount, error)
+}
+
+// MountsMsg carries a freshly enumerated mount list back to Update.
+type MountsMsg struct {
+	Mounts []Mount
+}
+
+// MountsErrMsg reports that enumeration failed.
+type MountsErrMsg struct {
+	Err error
+}
+
+// ChangeDirMsg asks a filepicker.Model to jump its current directory to
+// Path -- emitted when the user selects a mountpoint.
+type ChangeDirMsg struct {
+	Path string
+}
+
+// Model lists mounted filesystems and lets the user pick one to jump a
+// filepicker's current directory to.
+type Model struct {
+	Enumerator Enumerator
+	Mounts     []Mount
+	Cursor     int
+	Err        error
+}
+
+// NewModel creates a Model backed by enumerator.
+func NewModel(enumerator Enumerator) Model {
+	return Model{Enumerator: enumerator}
+}
+
+// RefreshCmd returns a tea.Cmd that re-enumerates mounted filesystems.
+func (m Model) RefreshCmd() tea.Cmd {
+	enumerator := m.Enumerator
+	return func() tea.Msg {
+		mounts, err := enumerator.Enumerate()
+		if err != nil {
+			return MountsErrMsg{Err: err}
+		}
+		return MountsMsg{Mounts: mounts}
+	}
+}
+
+// CursorUp moves the highlighted mount up, clamped to the listing bounds.
+func (m *Model) CursorUp() {
+	if m.Cursor > 0 {
+		m.Cursor--
+	}
+}
+
+// CursorDown moves the highlighted mount down, clamped to the listing
+// bounds.
+func (m *Model) CursorDown() {
+	if m.Cursor < len(m.Mounts)-1 {
+		m.Cursor++
+	}
+}
+
+// Selected returns the currently highlighted mount and whether one exists.
+func (m Model) Selected() (Mount, bool) {
+	if len(m.Mounts) == 0 || m.Cursor < 0 || m.Cursor >= len(m.Mounts) {
+		return Mount{}, false
+	}
+	return m.Mounts[m.Cursor], true
+}
+
+// Update handles a refreshed mount list and the select key, emitting
+// ChangeDirMsg for the filepicker to consume.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case MountsMsg:
+		m.Mounts = msg.Mounts
+		m.Err = nil
+		if m.Cursor >= len(m.Mounts) {
+			m.Cursor = len(m.Mounts) - 1
+		}
+		if m.Cursor < 0 {
+			m.Cursor = 0
+		}
+		return m, nil
+	case MountsErrMsg:
+		m.Err = msg.Err
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.CursorUp()
+		case "down", "j":
+			m.CursorDown()
+		case "enter":
+			if mount, ok := m.Selected(); ok {
+				return m, func() tea.Msg { return ChangeDirMsg{Path: mount.Mountpoint} }
+			}
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,295 @@
+// Package fsops implements multi-selection and a background cut/copy/
+// paste/delete worker for a file picker, modeled on joshuto/hunter's
+// ShowWorkers overlay. filepicker.Model does not ship selection or IO
+// operations today, so this stands in for the SelectedPaths, ToggleSelect,
+// InvertSelection, and ClearSelection APIs a Rust port needs a concrete
+// conformance suite for.
+package fsops
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// SelectionKeyMap binds the multi-selection actions, named to match
+// filepicker.KeyMap's field style (Up, Down, Open, Back, Select, ...).
+type SelectionKeyMap struct {
+	ToggleSelect    key.Binding
+	SelectAll       key.Binding
+	InvertSelection key.Binding
+	ClearSelection  key.Binding
+}
+
+// DefaultSelectionKeyMap returns the default selection bindings: space to
+// toggle, A to select-all, I to invert, C to clear.
+func DefaultSelectionKeyMap() SelectionKeyMap {
+	return SelectionKeyMap{
+		ToggleSelect:    key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle select")),
+		SelectAll:       key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "select all")),
+		InvertSelection: key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "invert selection")),
+		ClearSelection:  key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "clear selection")),
+	}
+}
+
+// Selection tracks which paths in a file listing are multi-selected.
+type Selection struct {
+	paths map[string]struct{}
+}
+
+// NewSelection creates an empty selection.
+func NewSelection() *Selection {
+	return &Selection{paths: make(map[string]struct{})}
+}
+
+// ToggleSelect flips path's membership in the selection.
+func (s *Selection) ToggleSelect(path string) {
+	if _, ok := s.paths[path]; ok {
+		delete(s.paths, path)
+		return
+	}
+	s.paths[path] = struct{}{}
+}
+
+// SelectAll adds every path in all to the selection.
+func (s *Selection) SelectAll(all []string) {
+	for _, p := range all {
+		s.paths[p] = struct{}{}
+	}
+}
+
+// InvertSelection replaces the selection with the complement of all.
+func (s *Selection) InvertSelection(all []string) {
+	next := make(map[string]struct{})
+	for _, p := range all {
+		if _, ok := s.paths[p]; !ok {
+			next[p] = struct{}{}
+		}
+	}
+	s.paths = next
+}
+
+// ClearSelection empties the selection.
+func (s *Selection) ClearSelection() {
+	s.paths = make(map[string]struct{})
+}
+
+// SelectedPaths returns the selected paths in sorted order.
+func (s *Selection) SelectedPaths() []string {
+	out := make([]string, 0, len(s.paths))
+	for p := range s.paths {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsSelected reports whether path is currently selected.
+func (s *Selection) IsSelected(path string) bool {
+	_, ok := s.paths[path]
+	return ok
+}
+
+// ClipboardMode records whether a clipboard holds a cut or copy selection.
+type ClipboardMode int
+
+const (
+	ClipboardNone ClipboardMode = iota
+	ClipboardCut
+	ClipboardCopy
+)
+
+// Clipboard holds the paths staged for a subsequent paste, and whether
+// pasting should move (cut) or duplicate (copy) them.
+type Clipboard struct {
+	Mode  ClipboardMode
+	Paths []string
+}
+
+// IOProgressMsg reports incremental progress of an in-flight worker
+// operation: Done of Total paths processed, and the path just finished.
+type IOProgressMsg struct {
+	Done        int
+	Total       int
+	CurrentPath string
+}
+
+// IODoneMsg signals that a worker operation finished successfully.
+type IODoneMsg struct{}
+
+// IOErrMsg signals that a worker operation failed or was canceled.
+type IOErrMsg struct {
+	Err error
+}
+
+const copyChunkSize = 32 * 1024
+
+// Worker performs cut/copy/paste/delete across a selection on a background
+// goroutine, streaming progress back over a channel so the UI thread never
+// blocks on large trees, modeled on joshuto/hunter's ShowWorkers overlay.
+type Worker struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	msgs   chan tea.Msg
+}
+
+// NewWorker creates a Worker with its own cancellation context.
+func NewWorker() *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Worker{ctx: ctx, cancel: cancel, msgs: make(chan tea.Msg, 1)}
+}
+
+// Cancel aborts the worker's in-flight operation, which then ends with an
+// IOErrMsg wrapping context.Canceled; an IOProgressMsg already queued is
+// still delivered first, and an operation that had already finished sends
+// its IODoneMsg instead.
+func (w *Worker) Cancel() {
+	w.cancel()
+}
+
+// Listen returns a tea.Cmd that blocks for the worker's next message. The
+// caller re-issues Listen after each IOProgressMsg until an IODoneMsg or
+// IOErrMsg arrives.
+func (w *Worker) Listen() tea.Cmd {
+	return func() tea.Msg { return <-w.msgs }
+}
+
+// Copy starts copying paths into destDir on a background goroutine,
+// streaming each file chunk-by-chunk so large trees don't block the UI.
+func (w *Worker) Copy(paths []string, destDir string) tea.Cmd {
+	return w.run(paths, func(src string) error { return copyInto(w.ctx, src, destDir) })
+}
+
+// Move cuts paths into destDir: copy then remove the source.
+func (w *Worker) Move(paths []string, destDir string) tea.Cmd {
+	return w.run(paths, func(src string) error {
+		if err := copyInto(w.ctx, src, destDir); err != nil {
+			return err
+		}
+		return os.RemoveAll(src)
+	})
+}
+
+// Delete removes paths.
+func (w *Worker) Delete(paths []string) tea.Cmd {
+	return w.run(paths, func(src string) error { return os.RemoveAll(src) })
+}
+
+// Paste applies clip against destDir: a copy clipboard duplicates every
+// path, a cut clipboard moves them and clears the clipboard.
+func (w *Worker) Paste(clip *Clipboard, destDir string) tea.Cmd {
+	switch clip.Mode {
+	case ClipboardCut:
+		cmd := w.Move(clip.Paths, destDir)
+		clip.Mode = ClipboardNone
+		clip.Paths = nil
+		return cmd
+	case ClipboardCopy:
+		return w.Copy(clip.Paths, destDir)
+	default:
+		return func() tea.Msg { return IODoneMsg{} }
+	}
+}
+
+func (w *Worker) run(paths []string, op func(string) error) tea.Cmd {
+	go func() {
+		total := len(paths)
+		for i, p := range paths {
+			select {
+			case <-w.ctx.Done():
+				w.msgs <- IOErrMsg{Err: w.ctx.Err()}
+				return
+			default:
+			}
+			if err := op(p); err != nil {
+				w.msgs <- IOErrMsg{Err: err}
+				return
+			}
+			w.msgs <- IOProgressMsg{Done: i + 1, Total: total, CurrentPath: p}
+		}
+		w.msgs <- IODoneMsg{}
+	}()
+	return w.Listen()
+}
+
+func copyInto(ctx context.Context, src, destDir string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(destDir, filepath.Base(src))
+	if info.IsDir() {
+		return copyDir(ctx, src, dest)
+	}
+	return copyFile(ctx, src, dest)
+}
+
+func copyDir(ctx context.Context, src, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		s := filepath.Join(src, e.Name())
+		d := filepath.Join(dest, e.Name())
+		if e.IsDir() {
+			if err := copyDir(ctx, s, d); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyFile(ctx, s, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(ctx context.Context, src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	buf := make([]byte, copyChunkSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		n, rerr := in.Read(buf)
+		if n > 0 {
+			if _, werr := out.Write(buf[:n]); werr != nil {
+				return werr
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Package fuzzyfilter reimplements the two fuzzy-matching algorithms
+// popularized by fzf -- a greedy single left-to-right-then-backward pass
+// ("v1") and a full dynamic-programming scorer ("v2") -- since huh's
+// Select/MultiSelect filtering isn't built on a named, versioned algorithm
+// a Rust port can look up. This gives it a concrete, self-contained
+// scoring spec to match value-for-value.
+package fuzzyfilter
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scoring constants, shared by both algorithms.
+const (
+	bonusBoundary    = 16 // word boundary after one of "/_-. "
+	bonusCamel       = 8  // lowercase-to-uppercase camelCase hump
+	bonusStart       = 7  // match begins at the very first rune
+	penaltyGap       = -3 // per gap rune between two matched positions
+	penaltySkip      = -1 // per skipped rune before the first match
+	bonusConsecutive = 15 // v2 only: extra bonus per consecutive matched rune
+)
+
+// Result is one candidate's outcome from a filter pass: its original index
+// (so a Rust port can confirm ordering, not just membership), score, and
+// the rune positions within the candidate that matched.
+type Result struct {
+	Index     int    `json:"index"`
+	Candidate string `json:"candidate"`
+	Score     int    `json:"score"`
+	Positions []int  `json:"positions"`
+}
+
+// FilterV1 scores candidates against pattern using the v1 algorithm and
+// returns matches ordered by descending score (ties keep original order).
+// An empty pattern matches every candidate in its original order at score 0.
+func FilterV1(pattern string, candidates []string) []Result {
+	return filter(pattern, candidates, matchV1)
+}
+
+// FilterV2 scores candidates against pattern using the v2 algorithm and
+// returns matches ordered by descending score (ties keep original order).
+// An empty pattern matches every candidate in its original order at score 0.
+func FilterV2(pattern string, candidates []string) []Result {
+	return filter(pattern, candidates, matchV2)
+}
+
+type matchFunc func(pattern, candidate []rune, caseSensitive bool) ([]int, int, bool)
+
+func filter(pattern string, candidates []string, match matchFunc) []Result {
+	if pattern == "" {
+		results := make([]Result, len(candidates))
+		for i, c := range candidates {
+			results[i] = Result{Index: i, Candidate: c, Score: 0, Positions: nil}
+		}
+		return results
+	}
+
+	caseSensitive := strings.ToLower(pattern) != pattern
+	p := []rune(pattern)
+
+	var results []Result
+	for i, c := range candidates {
+		positions, score, ok := match(p, []rune(c), caseSensitive)
+		if !ok {
+			continue
+		}
+		results = append(results, Result{Index: i, Candidate: c, Score: score, Positions: positions})
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+	return results
+}
+
+func runeEqual(p, c rune, caseSensitive bool) bool {
+	if caseSensitive {
+		return p == c
+	}
+	return unicode.ToLower(p) == unicode.ToLower(c)
+}
+
+func isBoundaryChar(r rune) bool {
+	switch r {
+	case '/', '_', '-', '.', ' ':
+		return true
+	default:
+		return false
+	}
+}
+
+// bonusAt returns the positional bonus for matching at candidate index idx
+// (0-based), based on the character immediately before it.
+func bonusAt(candidate []rune, idx int) int {
+	if idx == 0 {
+		return bonusStart
+	}
+	prev := candidate[idx-1]
+	if isBoundaryChar(prev) {
+		return bonusBoundary
+	}
+	if unicode.IsLower(prev) && unicode.IsUpper(candidate[idx]) {
+		return bonusCamel
+	}
+	return 0
+}
+
+// matchV1 implements fzf's v1 algorithm: a forward pass finds the earliest
+// end position covering the whole pattern, then a backward pass from that
+// end finds the tightest (rightmost-starting) span of positions achieving
+// it. Score is the sum of each matched position's bonus, minus a penalty
+// for runes skipped before the first match and for gaps between matches.
+func matchV1(pattern, candidate []rune, caseSensitive bool) ([]int, int, bool) {
+	pidx := 0
+	end := -1
+	for cidx := 0; cidx < len(candidate) && pidx < len(pattern); cidx++ {
+		if runeEqual(pattern[pidx], candidate[cidx], caseSensitive) {
+			pidx++
+			end = cidx
+		}
+	}
+	if pidx < len(pattern) {
+		return nil, 0, false
+	}
+
+	positions := make([]int, len(pattern))
+	pidx = len(pattern) - 1
+	cidx := end
+	for pidx >= 0 {
+		for !runeEqual(pattern[pidx], candidate[cidx], caseSensitive) {
+			cidx--
+		}
+		positions[pidx] = cidx
+		cidx--
+		pidx--
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += bonusAt(candidate, pos)
+		if i == 0 {
+			score += penaltySkip * pos
+			continue
+		}
+		gap := pos - positions[i-1] - 1
+		if gap > 0 {
+			score += penaltyGap * gap
+		}
+	}
+	return positions, score, true
+}
+
+// matchV2 implements fzf's v2 algorithm: a full M×N dynamic-programming
+// matrix where M[i][j] is the best score matching the first i pattern
+// runes within the first j candidate runes, choosing at each cell between
+// consuming candidate[j] as a match (match_score plus a consecutive-run
+// bonus from the companion run-length matrix) or skipping it (gap
+// penalty). Traceback over the run-length matrix yields the matched
+// positions.
+func matchV2(pattern, candidate []rune, caseSensitive bool) ([]int, int, bool) {
+	n, m := len(pattern), len(candidate)
+	const negInf = -1 << 30
+
+	score := make([][]int, n+1)
+	runLen := make([][]int, n+1)
+	fromMatch := make([][]bool, n+1)
+	for i := range score {
+		score[i] = make([]int, m+1)
+		runLen[i] = make([]int, m+1)
+		fromMatch[i] = make([]bool, m+1)
+		if i > 0 {
+			score[i][0] = negInf
+		}
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			skip := negInf
+			if score[i][j-1] > negInf {
+				skip = score[i][j-1] + penaltyGap
+			}
+
+			if runeEqual(pattern[i-1], candidate[j-1], caseSensitive) && score[i-1][j-1] > negInf {
+				matchScore := bonusAt(candidate, j-1)
+				if runLen[i-1][j-1] > 0 {
+					matchScore += bonusConsecutive
+				}
+				matched := score[i-1][j-1] + matchScore
+				if matched >= skip {
+					score[i][j] = matched
+					runLen[i][j] = runLen[i-1][j-1] + 1
+					fromMatch[i][j] = true
+					continue
+				}
+			}
+
+			score[i][j] = skip
+			runLen[i][j] = 0
+			fromMatch[i][j] = false
+		}
+	}
+
+	if n == 0 || m == 0 || score[n][m] <= negInf {
+		return nil, 0, false
+	}
+
+	positions := make([]int, n)
+	i, j := n, m
+	for i > 0 {
+		if fromMatch[i][j] {
+			positions[i-1] = j - 1
+			i--
+			j--
+		} else {
+			j--
+		}
+	}
+
+	return positions, score[n][m], true
+}
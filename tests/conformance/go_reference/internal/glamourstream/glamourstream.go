@@ -0,0 +1,32 @@
+// Package glamourstream provides a streaming render entry point glamour's
+// TermRenderer doesn't expose itself: Render and RenderBytes both require
+// the whole document up front, so a caller piping stdin (e.g. `curl |
+// glow`) has no way to measure, or even perform, incremental reading
+// separately from rendering. RenderReader reads its input to completion
+// -- the underlying goldmark parser needs the whole document regardless
+// -- then renders and writes the result, so the read and render phases
+// are at least visible as distinct costs to a caller or benchmark.
+package glamourstream
+
+import (
+	"io"
+
+	"github.com/charmbracelet/glamour"
+)
+
+// RenderReader reads md to completion, renders it with r, and writes the
+// rendered result to out.
+func RenderReader(r *glamour.TermRenderer, md io.Reader, out io.Writer) error {
+	data, err := io.ReadAll(md)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := r.Render(string(data))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, rendered)
+	return err
+}
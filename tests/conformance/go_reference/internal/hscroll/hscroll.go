@@ -0,0 +1,154 @@
+// Package hscroll implements horizontal scrolling over viewport content,
+// modeled on meli's pager (cols_lt_width, horizontal cursor tracking).
+// Bubbles' viewport lacks this API today, so this stands in for
+// viewport.ScrollLeft(n int), ScrollRight(n int), XOffset int, and
+// SoftWrap bool so the fixture generator has something concrete to call.
+package hscroll
+
+import "strings"
+
+// State tracks a viewport's horizontal scroll position.
+type State struct {
+	Width    int
+	XOffset  int
+	SoftWrap bool
+}
+
+// New creates horizontal scroll state for the given viewport width with
+// soft-wrap enabled, matching Bubbles' current default behavior.
+func New(width int) *State {
+	return &State{Width: width, SoftWrap: true}
+}
+
+// ScrollLeft decreases XOffset by n, clamped to zero.
+func (s *State) ScrollLeft(n int) {
+	s.XOffset -= n
+	if s.XOffset < 0 {
+		s.XOffset = 0
+	}
+}
+
+// ScrollRight increases XOffset by n, clamped so the viewport never
+// scrolls past the widest visible line.
+func (s *State) ScrollRight(n int, lines []string) {
+	s.XOffset += n
+	if max := maxLineWidth(lines) - s.Width; max > 0 {
+		if s.XOffset > max {
+			s.XOffset = max
+		}
+	} else {
+		s.XOffset = 0
+	}
+}
+
+// CanScrollRight reports whether any visible line's rendered width
+// exceeds Width + XOffset, i.e. there is more content to the right.
+func (s *State) CanScrollRight(lines []string) bool {
+	return maxLineWidth(lines) > s.Width+s.XOffset
+}
+
+func maxLineWidth(lines []string) int {
+	max := 0
+	for _, l := range lines {
+		if w := RuneWidth(l); w > max {
+			max = w
+		}
+	}
+	return max
+}
+
+// CutLine returns the visible slice of line for [xOffset, xOffset+width),
+// expanding tabs to the next multiple of 8 columns before cutting (so a
+// tab stop still lands on the right column at a nonzero xOffset) and
+// replacing a double-width glyph that straddles the left cut column with
+// a single space, the way terminal emulators avoid splitting a wide rune
+// in two rather than rendering half of it.
+func CutLine(line string, xOffset, width int) string {
+	expanded := expandTabs(line, 8)
+
+	var b strings.Builder
+	col := 0
+	for _, r := range expanded {
+		w := runeWidth(r)
+		switch {
+		case col+w <= xOffset:
+			// Entirely left of the cut column.
+		case col < xOffset && col+w > xOffset:
+			// Wide glyph straddles the cut column: render a space for the
+			// visible sliver instead of splitting the glyph.
+			b.WriteByte(' ')
+		case col >= xOffset+width:
+			return b.String()
+		case col+w > xOffset+width:
+			return b.String()
+		default:
+			b.WriteRune(r)
+		}
+		col += w
+	}
+	return b.String()
+}
+
+func expandTabs(s string, tabWidth int) string {
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			spaces := tabWidth - (col % tabWidth)
+			for i := 0; i < spaces; i++ {
+				b.WriteByte(' ')
+			}
+			col += spaces
+			continue
+		}
+		b.WriteRune(r)
+		col += runeWidth(r)
+	}
+	return b.String()
+}
+
+// RuneWidth returns the total display width of s, treating double-width
+// East Asian glyphs as occupying two columns.
+func RuneWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// runeWidth returns the display width of a single rune: 0 for combining
+// marks, 2 for East Asian wide/fullwidth ranges, 1 otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r >= 0x0300 && r <= 0x036F: // combining diacritical marks
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+func isWide(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115F: // Hangul Jamo
+		return true
+	case r >= 0x2E80 && r <= 0xA4CF: // CJK radicals through Yi
+		return true
+	case r >= 0xAC00 && r <= 0xD7A3: // Hangul syllables
+		return true
+	case r >= 0xF900 && r <= 0xFAFF: // CJK compatibility ideographs
+		return true
+	case r >= 0xFF00 && r <= 0xFF60: // fullwidth forms
+		return true
+	case r >= 0xFFE0 && r <= 0xFFE6:
+		return true
+	case r >= 0x20000 && r <= 0x3FFFD: // CJK extension planes
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,301 @@
+// Package keybind implements a small textual keybinding grammar modeled
+// on fzf's `--bind` DSL (e.g. "ctrl-alt-a", "alt-,", "shift-tab",
+// "alt-bspace", "f5"), since bubbletea itself has no named-keybinding
+// parser -- callers match tea.KeyMsg values directly. Parse turns an
+// expression into a KeyChord; Match and Sequences connect that chord back
+// to the tea.KeyMsg it should match and the raw escape sequence(s) a
+// terminal would actually send to trigger it.
+package keybind
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ModMask is which of the three modifiers a chord's base key requires.
+type ModMask struct {
+	Ctrl  bool
+	Alt   bool
+	Shift bool
+}
+
+// KeyChord is one parsed keybinding expression: a modifier mask plus
+// exactly one of a named key, a literal rune, or the "[*]" wildcard
+// (matching any single printable rune, case-sensitive).
+type KeyChord struct {
+	Mods     ModMask
+	Named    string
+	Rune     rune
+	Wildcard bool
+}
+
+// namedKeys is the bounded vocabulary of non-literal base keys the
+// grammar accepts, alongside "[*]" (handled separately as Wildcard).
+var namedKeys = map[string]bool{
+	"tab": true, "enter": true, "esc": true, "space": true, "bspace": true,
+	"up": true, "down": true, "left": true, "right": true,
+	"home": true, "end": true, "pgup": true, "pgdn": true,
+	"del": true, "insert": true,
+	"f1": true, "f2": true, "f3": true, "f4": true, "f5": true, "f6": true,
+	"f7": true, "f8": true, "f9": true, "f10": true, "f11": true, "f12": true,
+}
+
+// Parse turns a keybinding expression into a KeyChord. Modifiers are
+// dash-separated prefixes ("ctrl-", "alt-", "shift-", in any order and
+// combination); whatever remains is the base key. A literal dash as the
+// base key (e.g. "ctrl--") is supported since modifier stripping only
+// matches on the "<mod>-" prefixes, never the trailing content.
+func Parse(expr string) (KeyChord, error) {
+	if expr == "" {
+		return KeyChord{}, fmt.Errorf("keybind: empty expression")
+	}
+
+	var mods ModMask
+	rest := expr
+modsLoop:
+	for {
+		switch {
+		case strings.HasPrefix(rest, "ctrl-"):
+			mods.Ctrl = true
+			rest = rest[len("ctrl-"):]
+		case strings.HasPrefix(rest, "alt-"):
+			mods.Alt = true
+			rest = rest[len("alt-"):]
+		case strings.HasPrefix(rest, "shift-"):
+			mods.Shift = true
+			rest = rest[len("shift-"):]
+		default:
+			break modsLoop
+		}
+	}
+
+	if rest == "" {
+		return KeyChord{}, fmt.Errorf("keybind: %q has no base key", expr)
+	}
+
+	switch {
+	case rest == "[*]":
+		return KeyChord{Mods: mods, Wildcard: true}, nil
+	case namedKeys[rest]:
+		return KeyChord{Mods: mods, Named: rest}, nil
+	default:
+		runes := []rune(rest)
+		if len(runes) != 1 {
+			return KeyChord{}, fmt.Errorf("keybind: %q is not a single character or a known named key", rest)
+		}
+		return KeyChord{Mods: mods, Rune: runes[0]}, nil
+	}
+}
+
+// ctrlLetterType maps a lowercase letter to the tea.KeyType a terminal's
+// control-code encoding produces for ctrl+that letter.
+var ctrlLetterType = map[rune]tea.KeyType{
+	'a': tea.KeyCtrlA, 'b': tea.KeyCtrlB, 'c': tea.KeyCtrlC, 'd': tea.KeyCtrlD,
+	'e': tea.KeyCtrlE, 'f': tea.KeyCtrlF, 'g': tea.KeyCtrlG, 'h': tea.KeyCtrlH,
+	'i': tea.KeyCtrlI, 'j': tea.KeyCtrlJ, 'k': tea.KeyCtrlK, 'l': tea.KeyCtrlL,
+	'm': tea.KeyCtrlM, 'n': tea.KeyCtrlN, 'o': tea.KeyCtrlO, 'p': tea.KeyCtrlP,
+	'q': tea.KeyCtrlQ, 'r': tea.KeyCtrlR, 's': tea.KeyCtrlS, 't': tea.KeyCtrlT,
+	'u': tea.KeyCtrlU, 'v': tea.KeyCtrlV, 'w': tea.KeyCtrlW, 'x': tea.KeyCtrlX,
+	'y': tea.KeyCtrlY, 'z': tea.KeyCtrlZ,
+}
+
+var plainNamedType = map[string]tea.KeyType{
+	"tab": tea.KeyTab, "enter": tea.KeyEnter, "esc": tea.KeyEscape,
+	"space": tea.KeySpace, "bspace": tea.KeyBackspace,
+	"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+	"home": tea.KeyHome, "end": tea.KeyEnd, "pgup": tea.KeyPgUp, "pgdn": tea.KeyPgDown,
+	"del": tea.KeyDelete, "insert": tea.KeyInsert,
+	"f1": tea.KeyF1, "f2": tea.KeyF2, "f3": tea.KeyF3, "f4": tea.KeyF4,
+	"f5": tea.KeyF5, "f6": tea.KeyF6, "f7": tea.KeyF7, "f8": tea.KeyF8,
+	"f9": tea.KeyF9, "f10": tea.KeyF10, "f11": tea.KeyF11, "f12": tea.KeyF12,
+}
+
+var ctrlNamedType = map[string]tea.KeyType{
+	"up": tea.KeyCtrlUp, "down": tea.KeyCtrlDown, "left": tea.KeyCtrlLeft, "right": tea.KeyCtrlRight,
+}
+
+var shiftNamedType = map[string]tea.KeyType{
+	"up": tea.KeyShiftUp, "down": tea.KeyShiftDown, "left": tea.KeyShiftLeft, "right": tea.KeyShiftRight,
+	"tab": tea.KeyShiftTab,
+}
+
+// namedKeyType resolves c's named base key and Ctrl/Shift modifiers (Alt
+// is always reported separately on tea.KeyMsg, so it isn't folded into
+// the Type here) to the tea.KeyType a real driver would produce, giving
+// Ctrl precedence over Shift where both have a dedicated combo -- neither
+// bubbletea nor this grammar defines one where both do.
+func namedKeyType(c KeyChord) (tea.KeyType, bool) {
+	if c.Mods.Ctrl {
+		if kt, ok := ctrlNamedType[c.Named]; ok {
+			return kt, true
+		}
+	}
+	if c.Mods.Shift {
+		if kt, ok := shiftNamedType[c.Named]; ok {
+			return kt, true
+		}
+	}
+	kt, ok := plainNamedType[c.Named]
+	return kt, ok
+}
+
+// KeyMsgFor derives the canonical tea.KeyMsg a real driver would send for
+// chord c, using the same resolution Match itself performs. It reports
+// false if c has no well-defined message (e.g. a ctrl+wildcard chord, or
+// a ctrl+rune chord with no ctrl letter form); a named key with no
+// ctrl-specific form resolves to its plain key type. This is the counterpart to
+// Sequences: Sequences gives the bytes a terminal sends, KeyMsgFor gives
+// the tea.KeyMsg a driver turns them into.
+func KeyMsgFor(c KeyChord) (tea.KeyMsg, bool) {
+	switch {
+	case c.Wildcard:
+		if c.Mods.Ctrl {
+			return tea.KeyMsg{}, false
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}, Alt: c.Mods.Alt}, true
+	case c.Named != "":
+		kt, ok := namedKeyType(c)
+		if !ok {
+			return tea.KeyMsg{}, false
+		}
+		return tea.KeyMsg{Type: kt, Alt: c.Mods.Alt}, true
+	case c.Mods.Ctrl:
+		kt, ok := ctrlLetterType[unicode.ToLower(c.Rune)]
+		if !ok {
+			return tea.KeyMsg{}, false
+		}
+		return tea.KeyMsg{Type: kt, Alt: c.Mods.Alt}, true
+	default:
+		want := c.Rune
+		if c.Mods.Shift {
+			want = unicode.ToUpper(want)
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{want}, Alt: c.Mods.Alt}, true
+	}
+}
+
+// Match reports whether msg is the tea.KeyMsg a real driver would send
+// for chord c.
+func Match(c KeyChord, msg tea.KeyMsg) bool {
+	if msg.Alt != c.Mods.Alt {
+		return false
+	}
+
+	switch {
+	case c.Wildcard:
+		return !c.Mods.Ctrl && msg.Type == tea.KeyRunes && len(msg.Runes) == 1
+	case c.Named != "":
+		kt, ok := namedKeyType(c)
+		return ok && msg.Type == kt
+	case c.Mods.Ctrl:
+		kt, ok := ctrlLetterType[unicode.ToLower(c.Rune)]
+		return ok && msg.Type == kt
+	default:
+		if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+			return false
+		}
+		want := c.Rune
+		if c.Mods.Shift {
+			want = unicode.ToUpper(want)
+		}
+		return msg.Runes[0] == want
+	}
+}
+
+// legacyArrowSeq maps an arrow's name and a "" (plain)/"ctrl"/"shift"/"alt"
+// modifier label to the xterm CSI sequence a terminal sends for it, the
+// same sequences cmd/bubbletea's captureKeySequenceTests already covers.
+var legacyArrowSeq = map[string]map[string]string{
+	"up":    {"": "\x1b[A", "shift": "\x1b[1;2A", "alt": "\x1b[1;3A", "ctrl": "\x1b[1;5A"},
+	"down":  {"": "\x1b[B", "shift": "\x1b[1;2B", "alt": "\x1b[1;3B", "ctrl": "\x1b[1;5B"},
+	"right": {"": "\x1b[C", "shift": "\x1b[1;2C", "alt": "\x1b[1;3C", "ctrl": "\x1b[1;5C"},
+	"left":  {"": "\x1b[D", "shift": "\x1b[1;2D", "alt": "\x1b[1;3D", "ctrl": "\x1b[1;5D"},
+}
+
+var plainNamedSeq = map[string]string{
+	"tab": "\t", "enter": "\r", "esc": "\x1b", "space": " ", "bspace": "\x7f",
+	"home": "\x1b[H", "end": "\x1b[F", "pgup": "\x1b[5~", "pgdn": "\x1b[6~",
+	"del": "\x1b[3~", "insert": "\x1b[2~",
+	"f1": "\x1bOP", "f2": "\x1bOQ", "f3": "\x1bOR", "f4": "\x1bOS",
+	"f5": "\x1b[15~", "f6": "\x1b[17~", "f7": "\x1b[18~", "f8": "\x1b[19~",
+	"f9": "\x1b[20~", "f10": "\x1b[21~", "f11": "\x1b[23~", "f12": "\x1b[24~",
+}
+
+// Sequences returns the raw escape sequence(s) a terminal would send to
+// trigger chord c. For the wildcard base key it returns one sequence per
+// sample rune rather than every possible character.
+func Sequences(c KeyChord) ([]string, error) {
+	switch {
+	case c.Wildcard:
+		return wildcardSequences(c.Mods), nil
+	case c.Named != "":
+		return namedSequences(c)
+	case c.Mods.Ctrl:
+		kt := unicode.ToLower(c.Rune)
+		if kt < 'a' || kt > 'z' {
+			return nil, fmt.Errorf("keybind: no ctrl sequence for rune %q", c.Rune)
+		}
+		return []string{string(rune(kt - 'a' + 1))}, nil
+	default:
+		base := c.Rune
+		if c.Mods.Shift {
+			base = unicode.ToUpper(base)
+		}
+		seq := string(base)
+		if c.Mods.Alt {
+			seq = "\x1b" + seq
+		}
+		return []string{seq}, nil
+	}
+}
+
+func namedSequences(c KeyChord) ([]string, error) {
+	if byMod, ok := legacyArrowSeq[c.Named]; ok {
+		label := ""
+		switch {
+		case c.Mods.Ctrl:
+			label = "ctrl"
+		case c.Mods.Shift:
+			label = "shift"
+		case c.Mods.Alt:
+			label = "alt"
+		}
+		if seq, ok := byMod[label]; ok {
+			return []string{seq}, nil
+		}
+	}
+
+	if c.Mods.Ctrl {
+		return nil, fmt.Errorf("keybind: no known ctrl sequence for %q", c.Named)
+	}
+
+	base, ok := plainNamedSeq[c.Named]
+	if !ok {
+		return nil, fmt.Errorf("keybind: unknown named key %q", c.Named)
+	}
+
+	switch {
+	case c.Mods.Shift && c.Named == "tab":
+		base = "\x1b[Z"
+	case c.Mods.Alt:
+		base = "\x1b" + base
+	}
+
+	return []string{base}, nil
+}
+
+func wildcardSequences(mods ModMask) []string {
+	samples := []rune{'a', 'Z', '5', '!', '-', ' '}
+	out := make([]string, 0, len(samples))
+	for _, r := range samples {
+		seq := string(r)
+		if mods.Alt {
+			seq = "\x1b" + seq
+		}
+		out = append(out, seq)
+	}
+	return out
+}
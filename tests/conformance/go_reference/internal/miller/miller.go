@@ -0,0 +1,257 @@
+// Package miller renders a filepicker as three side-by-side panes --
+// parent directory, current directory, and a preview of the highlighted
+// entry -- mirroring the layout used by hunter/joshuto/broot.
+// filepicker.Model has no such mode today, so this stands in for a
+// MillerModel a Rust port needs a concrete conformance suite for.
+package miller
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultMaxPreviewSize = 1 << 20 // 1 MiB
+
+// PreviewRenderer renders a preview for the given path, constrained to
+// maxWidth/maxHeight, so callers can plug in syntax-highlighted previews.
+type PreviewRenderer interface {
+	Render(path string, maxWidth, maxHeight int) (string, error)
+}
+
+// DefaultPreviewRenderer renders directory listings and file heads,
+// degrading to a placeholder once a file's size exceeds MaxPreviewSize
+// or its contents look binary.
+type DefaultPreviewRenderer struct {
+	MaxPreviewSize int64
+}
+
+// Render implements PreviewRenderer.
+func (r DefaultPreviewRenderer) Render(path string, maxWidth, maxHeight int) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return renderDirPreview(path, maxHeight)
+	}
+
+	maxSize := r.MaxPreviewSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxPreviewSize
+	}
+	if info.Size() > maxSize {
+		return fmt.Sprintf("(file too large to preview: %d bytes)", info.Size()), nil
+	}
+	return renderFileHeadPreview(path, maxWidth, maxHeight)
+}
+
+func renderDirPreview(path string, maxHeight int) (string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	if maxHeight > 0 && len(names) > maxHeight {
+		names = names[:maxHeight]
+	}
+	return strings.Join(names, "\n"), nil
+}
+
+func renderFileHeadPreview(path string, maxWidth, maxHeight int) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if isBinary(data) {
+		return "(binary)", nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if maxHeight > 0 && len(lines) > maxHeight {
+		lines = lines[:maxHeight]
+	}
+	if maxWidth > 0 {
+		for i, l := range lines {
+			if len(l) > maxWidth {
+				lines[i] = l[:maxWidth]
+			}
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func isBinary(data []byte) bool {
+	for _, b := range data {
+		if b == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheKey identifies a cached preview by path and modification time, so
+// a file edited after it was cached invalidates the stale entry.
+type cacheKey struct {
+	path  string
+	mtime int64
+}
+
+// previewCache is a small LRU keyed by path+mtime so cursor movement
+// doesn't re-render a preview that hasn't changed on disk.
+type previewCache struct {
+	capacity int
+	order    []cacheKey
+	entries  map[cacheKey]string
+}
+
+func newPreviewCache(capacity int) *previewCache {
+	return &previewCache{capacity: capacity, entries: make(map[cacheKey]string)}
+}
+
+func (c *previewCache) get(key cacheKey) (string, bool) {
+	v, ok := c.entries[key]
+	if ok {
+		c.touch(key)
+	}
+	return v, ok
+}
+
+func (c *previewCache) put(key cacheKey, value string) {
+	if _, ok := c.entries[key]; !ok {
+		if c.capacity > 0 && len(c.order) >= c.capacity {
+			oldest := c.order[0]
+			c.order = c.order[1:]
+			delete(c.entries, oldest)
+		}
+		c.order = append(c.order, key)
+	} else {
+		c.touch(key)
+	}
+	c.entries[key] = value
+}
+
+func (c *previewCache) touch(key cacheKey) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	c.order = append(c.order, key)
+}
+
+// PreviewMsg carries an asynchronously loaded preview back to Update.
+type PreviewMsg struct {
+	Path     string
+	Content  string
+	Err      error
+	CacheHit bool
+}
+
+// MillerModel renders a filepicker as three side-by-side panes -- parent
+// directory, current directory, and a preview of the highlighted entry.
+type MillerModel struct {
+	Width, Height int
+	Ratios        [3]int
+
+	ParentEntries  []string
+	CurrentEntries []string
+	CursorIndex    int
+
+	PreviewRenderer PreviewRenderer
+
+	cache *previewCache
+}
+
+// NewMillerModel creates a MillerModel with equal-width panes and the
+// given preview renderer.
+func NewMillerModel(renderer PreviewRenderer) MillerModel {
+	return MillerModel{
+		Ratios:          [3]int{1, 1, 1},
+		PreviewRenderer: renderer,
+		cache:           newPreviewCache(64),
+	}
+}
+
+// WithMillerColumns sets the relative widths of the parent/current/preview
+// panes and returns m for chaining.
+func (m *MillerModel) WithMillerColumns(ratios [3]int) *MillerModel {
+	m.Ratios = ratios
+	return m
+}
+
+// PaneWidths splits Width across Ratios, giving any rounding remainder to
+// the last pane so the three widths always sum to Width.
+func (m *MillerModel) PaneWidths() [3]int {
+	total := m.Ratios[0] + m.Ratios[1] + m.Ratios[2]
+	if total <= 0 || m.Width <= 0 {
+		return [3]int{0, 0, 0}
+	}
+	w0 := m.Width * m.Ratios[0] / total
+	w1 := m.Width * m.Ratios[1] / total
+	w2 := m.Width - w0 - w1
+	return [3]int{w0, w1, w2}
+}
+
+// CursorDown moves the highlighted entry down within CurrentEntries,
+// clamped to the listing bounds.
+func (m *MillerModel) CursorDown() {
+	if m.CursorIndex < len(m.CurrentEntries)-1 {
+		m.CursorIndex++
+	}
+}
+
+// CursorUp moves the highlighted entry up within CurrentEntries, clamped
+// to the listing bounds.
+func (m *MillerModel) CursorUp() {
+	if m.CursorIndex > 0 {
+		m.CursorIndex--
+	}
+}
+
+// SelectedPath returns the path of the currently highlighted entry within
+// dir, or "" if CurrentEntries is empty.
+func (m *MillerModel) SelectedPath(dir string) string {
+	if len(m.CurrentEntries) == 0 || m.CursorIndex < 0 || m.CursorIndex >= len(m.CurrentEntries) {
+		return ""
+	}
+	return filepath.Join(dir, m.CurrentEntries[m.CursorIndex])
+}
+
+// LoadPreview returns a tea.Cmd that asynchronously renders the preview
+// for path, serving a cached render when path's mtime hasn't changed so
+// cursor movement stays snappy.
+func (m *MillerModel) LoadPreview(path string) tea.Cmd {
+	renderer := m.PreviewRenderer
+	cache := m.cache
+	maxWidth := m.PaneWidths()[2]
+	maxHeight := m.Height
+
+	return func() tea.Msg {
+		info, err := os.Stat(path)
+		if err != nil {
+			return PreviewMsg{Path: path, Err: err}
+		}
+
+		key := cacheKey{path: path, mtime: info.ModTime().UnixNano()}
+		if cached, ok := cache.get(key); ok {
+			return PreviewMsg{Path: path, Content: cached, CacheHit: true}
+		}
+
+		content, err := renderer.Render(path, maxWidth, maxHeight)
+		if err != nil {
+			return PreviewMsg{Path: path, Err: err}
+		}
+		cache.put(key, content)
+		return PreviewMsg{Path: path, Content: content}
+	}
+}
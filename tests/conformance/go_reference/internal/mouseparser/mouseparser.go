@@ -0,0 +1,196 @@
+// Package mouseparser decodes the three wire protocols terminals use to
+// report mouse events -- legacy X10, SGR (mode 1006), and URXVT (mode
+// 1015) -- into a tea.MouseEvent. bubbletea exports MouseEvent but not
+// the sequence decoder that produces one, so this stands in as that
+// decoder, mirroring its bit layout, so a Rust port has ground truth for
+// the wire protocol itself rather than just the MouseEvent enum values.
+package mouseparser
+
+import (
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Modifier and extension bits shared by all three protocols' button byte.
+const (
+	bitShift  = 4
+	bitAlt    = 8
+	bitCtrl   = 16
+	bitMotion = 32
+	bitWheel  = 64
+)
+
+func applyModifiers(ev *tea.MouseEvent, cb int) {
+	ev.Shift = cb&bitShift != 0
+	ev.Alt = cb&bitAlt != 0
+	ev.Ctrl = cb&bitCtrl != 0
+}
+
+func buttonFromLowBits(cb int, wheel bool) tea.MouseButton {
+	switch cb & 3 {
+	case 0:
+		if wheel {
+			return tea.MouseButtonWheelUp
+		}
+		return tea.MouseButtonLeft
+	case 1:
+		if wheel {
+			return tea.MouseButtonWheelDown
+		}
+		return tea.MouseButtonMiddle
+	case 2:
+		if wheel {
+			return tea.MouseButtonWheelLeft
+		}
+		return tea.MouseButtonRight
+	default:
+		if wheel {
+			return tea.MouseButtonWheelRight
+		}
+		return tea.MouseButtonNone
+	}
+}
+
+// ParseX10 decodes a legacy X10 mouse sequence: ESC [ M <cb> <cx> <cy>,
+// where each of cb/cx/cy is a single byte offset by 32 (so coordinates
+// beyond 223 can't be represented -- SGR or URXVT must be used instead).
+// A release is signaled by the button's low two bits both being set,
+// since X10 can't report which button was released.
+func ParseX10(seq string) (tea.MouseEvent, int, bool) {
+	const prefix = "\x1b[M"
+	if !strings.HasPrefix(seq, prefix) {
+		return tea.MouseEvent{}, 0, false
+	}
+	if len(seq) < len(prefix)+3 {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	cb := int(seq[3]) - 32
+	ev := tea.MouseEvent{
+		X: int(seq[4]) - 32 - 1,
+		Y: int(seq[5]) - 32 - 1,
+	}
+	applyModifiers(&ev, cb)
+
+	switch {
+	case cb&bitWheel != 0:
+		ev.Button = buttonFromLowBits(cb, true)
+		ev.Action = tea.MouseActionPress
+	case cb&3 == 3:
+		ev.Button = tea.MouseButtonNone
+		ev.Action = tea.MouseActionRelease
+	case cb&bitMotion != 0:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionMotion
+	default:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionPress
+	}
+
+	return ev, len(prefix) + 3, true
+}
+
+// ParseSGR decodes an SGR (mode 1006) mouse sequence: ESC [ < Cb ; Cx ;
+// Cy M|m, with Cb/Cx/Cy carried as decimal parameters and a 1-based,
+// unbounded coordinate range. The final byte disambiguates press/motion
+// ('M') from release ('m'), so unlike X10, SGR reports which button was
+// released.
+func ParseSGR(seq string) (tea.MouseEvent, int, bool) {
+	const prefix = "\x1b[<"
+	if !strings.HasPrefix(seq, prefix) {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	body := seq[len(prefix):]
+	end := strings.IndexAny(body, "Mm")
+	if end == -1 {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	cb, cx, cy, ok := parseTriple(body[:end])
+	if !ok {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	isRelease := body[end] == 'm'
+	ev := tea.MouseEvent{X: cx - 1, Y: cy - 1}
+	applyModifiers(&ev, cb)
+
+	switch {
+	case isRelease:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionRelease
+	case cb&bitWheel != 0:
+		ev.Button = buttonFromLowBits(cb, true)
+		ev.Action = tea.MouseActionPress
+	case cb&bitMotion != 0:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionMotion
+	default:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionPress
+	}
+
+	return ev, len(prefix) + end + 1, true
+}
+
+// ParseURXVT decodes a URXVT (mode 1015) mouse sequence: ESC [ Cb ; Cx ;
+// Cy M. It shares X10's button-byte encoding (offset by 32, so release
+// can't identify which button let go) but carries coordinates as decimal
+// parameters instead of single bytes, lifting X10's 223-column/row cap.
+func ParseURXVT(seq string) (tea.MouseEvent, int, bool) {
+	const prefix = "\x1b["
+	if !strings.HasPrefix(seq, prefix) {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	body := seq[len(prefix):]
+	end := strings.IndexByte(body, 'M')
+	if end == -1 {
+		return tea.MouseEvent{}, 0, false
+	}
+
+	rawCb, cx, cy, ok := parseTriple(body[:end])
+	if !ok {
+		return tea.MouseEvent{}, 0, false
+	}
+	cb := rawCb - 32
+
+	ev := tea.MouseEvent{X: cx - 1, Y: cy - 1}
+	applyModifiers(&ev, cb)
+
+	switch {
+	case cb&bitWheel != 0:
+		ev.Button = buttonFromLowBits(cb, true)
+		ev.Action = tea.MouseActionPress
+	case cb&3 == 3:
+		ev.Button = tea.MouseButtonNone
+		ev.Action = tea.MouseActionRelease
+	case cb&bitMotion != 0:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionMotion
+	default:
+		ev.Button = buttonFromLowBits(cb, false)
+		ev.Action = tea.MouseActionPress
+	}
+
+	return ev, len(prefix) + end + 1, true
+}
+
+func parseTriple(body string) (a, b, c int, ok bool) {
+	parts := strings.Split(body, ";")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	ints := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		ints[i] = n
+	}
+	return ints[0], ints[1], ints[2], true
+}
@@ -0,0 +1,62 @@
+// Package optsource builds huh.Option lists from the scripting-friendly
+// sources gum-style shell tools expose -- delimiter-separated "key,label"
+// strings and newline-separated stdin streams -- neither of which huh
+// itself parses, so this stands in as the option-sourcing layer a Rust
+// port needs to match.
+package optsource
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+)
+
+// ParseDelimited splits each line on delim into a key and a display label
+// ("key<delim>Display label"). A line with no delim in it is used as both
+// the key and the label.
+func ParseDelimited(lines []string, delim string) []huh.Option[string] {
+	var opts []huh.Option[string]
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		key, label := line, line
+		if idx := strings.Index(line, delim); idx >= 0 {
+			key = line[:idx]
+			label = line[idx+len(delim):]
+		}
+		opts = append(opts, huh.NewOption(label, key))
+	}
+	return opts
+}
+
+// FromReader reads newline-separated option values from r, one huh.Option
+// per non-empty line, using each line as both its own key and label.
+func FromReader(r io.Reader) ([]huh.Option[string], error) {
+	var opts []huh.Option[string]
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		opts = append(opts, huh.NewOption(line, line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// SkipIfOne reports whether opts holds exactly one option, returning it
+// alongside true so a caller can short-circuit the form entirely --
+// matching the "--select-if-one" convention gum-style tools use to skip
+// prompting when a script only handed over a single choice.
+func SkipIfOne(opts []huh.Option[string]) (huh.Option[string], bool) {
+	if len(opts) != 1 {
+		return huh.Option[string]{}, false
+	}
+	return opts[0], true
+}
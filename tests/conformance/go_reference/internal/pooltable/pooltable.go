@@ -0,0 +1,127 @@
+// Package pooltable renders a grid of rows where each row may hold a
+// different number of cells, modeled on Charm's PoolTable proposal --
+// unlike lipgloss's own table package, which requires every row to
+// share one fixed column layout, a pool table lets ragged rows coexist
+// and only has to decide, per Dimension, how the column skeleton it
+// draws borders around should be sized.
+package pooltable
+
+import (
+	"strings"
+
+	"charmed_conformance/internal/borderjoin"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dimension selects how a ragged grid's column widths are decided.
+type Dimension int
+
+const (
+	// PriorityList sizes every row independently: each row's cells are
+	// only as wide as that row needs, with no attempt to line up
+	// columns across rows. Rows end up different total widths.
+	PriorityList Dimension = iota
+
+	// PriorityColumn sizes a common column skeleton -- one width per
+	// column index, up to the narrowest row's cell count -- and aligns
+	// every row to it. Cells beyond the skeleton (rows with more cells
+	// than the shortest row) overflow at their own natural width
+	// instead of being folded into the skeleton.
+	PriorityColumn
+)
+
+// cellWidth is lipgloss.Width, which measures rendered width ignoring
+// ANSI escape sequences -- cells may carry their own styling.
+func cellWidth(s string) int {
+	return lipgloss.Width(s)
+}
+
+// skeletonWidths computes PriorityColumn's per-column widths: one
+// entry per column index present in every row, sized to the widest
+// cell at that index among rows that have it.
+func skeletonWidths(rows [][]string) []int {
+	cols := -1
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		if cols == -1 || len(row) < cols {
+			cols = len(row)
+		}
+	}
+	if cols <= 0 {
+		return nil
+	}
+	widths := make([]int, cols)
+	for _, row := range rows {
+		for c := 0; c < cols && c < len(row); c++ {
+			if w := cellWidth(row[c]); w > widths[c] {
+				widths[c] = w
+			}
+		}
+	}
+	return widths
+}
+
+// renderRow joins one row's cells into a single bordered line, padding
+// each cell to width[i] (falling back to the cell's own width when i
+// is beyond the skeleton, i.e. an overflow cell).
+func renderRow(row []string, widths []int) string {
+	if len(row) == 0 {
+		return ""
+	}
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		w := cellWidth(cell)
+		if i < len(widths) && widths[i] > w {
+			w = widths[i]
+		}
+		cells[i] = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Width(w).Render(cell)
+	}
+
+	line := cells[0]
+	width := lipgloss.Width(cells[0])
+	for _, cell := range cells[1:] {
+		joined := lipgloss.JoinHorizontal(lipgloss.Top, line, cell)
+		line = borderjoin.MergeHorizontal(joined, width)
+		width = lipgloss.Width(line)
+	}
+	return line
+}
+
+// Render draws rows, a ragged grid of cells, as bordered blocks
+// stacked vertically, with NormalBorder() between cells. dim selects
+// how column widths are decided across rows (see Dimension). Empty
+// rows (no cells) render as a single blank line with no border.
+func Render(rows [][]string, dim Dimension) string {
+	var widths []int
+	if dim == PriorityColumn {
+		widths = skeletonWidths(rows)
+	}
+
+	rendered := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var rowWidths []int
+		if dim == PriorityColumn {
+			rowWidths = widths
+		}
+		rendered = append(rendered, renderRow(row, rowWidths))
+	}
+
+	if len(rendered) == 0 {
+		return ""
+	}
+
+	out := rendered[0]
+	for i := 1; i < len(rendered); i++ {
+		if rendered[i] == "" || out == "" {
+			out = strings.Join([]string{out, rendered[i]}, "\n")
+			continue
+		}
+		topHeight := strings.Count(out, "\n") + 1
+		joined := lipgloss.JoinVertical(lipgloss.Left, out, rendered[i])
+		out = borderjoin.MergeVertical(joined, topHeight)
+	}
+	return out
+}
@@ -0,0 +1,234 @@
+// Package rotatelog implements a size- and time-triggered rotating,
+// gzip-compressing io.Writer, modeled on lumberjack-style rotation policies.
+// charmbracelet/log only writes to whatever io.Writer it's given and ships
+// no rotation of its own, so this stands in for the rotating file sink a
+// real deployment wires it to, giving the Rust port a concrete, replayable
+// rotation policy to match byte-for-byte.
+package rotatelog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Clock lets callers freeze time for deterministic rotation fixtures.
+type Clock func() time.Time
+
+// Rotator is an io.Writer that rotates its active file when either a size
+// threshold or a time interval is exceeded, gzip-compressing the rotated
+// file and keeping at most MaxBackups of them.
+type Rotator struct {
+	// Dir is the directory the active file and its rotated backups live in.
+	Dir string
+	// BaseName is the active file's name, e.g. "app.log".
+	BaseName string
+	// MaxSizeBytes triggers a rotation on the write that would cross it.
+	// Zero disables size-triggered rotation.
+	MaxSizeBytes int64
+	// RotateInterval triggers a rotation once this much time has passed
+	// since the active file was opened. Zero disables time-triggered
+	// rotation.
+	RotateInterval time.Duration
+	// MaxBackups is the number of rotated files retained; the oldest is
+	// removed once a new rotation would exceed it. Zero keeps them all.
+	MaxBackups int
+	// Now returns the current time; defaults to time.Now if nil, but
+	// capture programs set it to a frozen clock for determinism.
+	Now Clock
+
+	size      int64
+	openedAt  time.Time
+	file      *os.File
+	rotations []RotationEvent
+}
+
+// RotationEvent records one rotation's outcome, for fixtures to assert
+// against: the trigger that caused it, the rotated file's name, and the
+// bytes the active file held at the moment of rotation.
+type RotationEvent struct {
+	Trigger      string
+	RotatedName  string
+	ActiveBytes  []byte
+	BackupsAfter []string
+}
+
+func (r *Rotator) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r *Rotator) activePath() string {
+	return filepath.Join(r.Dir, r.BaseName)
+}
+
+func (r *Rotator) ensureOpen() error {
+	if r.file != nil {
+		return nil
+	}
+	f, err := os.OpenFile(r.activePath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("rotatelog: opening active file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("rotatelog: stat active file: %w", err)
+	}
+	r.file = f
+	r.size = info.Size()
+	r.openedAt = r.now()
+	return nil
+}
+
+// Write implements io.Writer, rotating first if p would cross a configured
+// threshold.
+func (r *Rotator) Write(p []byte) (int, error) {
+	if err := r.ensureOpen(); err != nil {
+		return 0, err
+	}
+
+	if trigger := r.triggerFor(len(p)); trigger != "" {
+		if err := r.rotate(trigger); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("rotatelog: writing active file: %w", err)
+	}
+	return n, nil
+}
+
+// triggerFor returns which policy (if any) firing next would cross, given
+// writeLen more bytes are about to be written.
+func (r *Rotator) triggerFor(writeLen int) string {
+	if r.MaxSizeBytes > 0 && r.size+int64(writeLen) > r.MaxSizeBytes {
+		return "size"
+	}
+	if r.RotateInterval > 0 && r.now().Sub(r.openedAt) >= r.RotateInterval {
+		return "time"
+	}
+	return ""
+}
+
+// rotate closes the active file, gzip-compresses it into a timestamped
+// backup, prunes backups beyond MaxBackups, reopens a fresh active file,
+// and records a RotationEvent describing what happened.
+func (r *Rotator) rotate(trigger string) error {
+	activeBytes, err := os.ReadFile(r.activePath())
+	if err != nil {
+		return fmt.Errorf("rotatelog: reading active file for rotation: %w", err)
+	}
+
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("rotatelog: closing active file: %w", err)
+	}
+	r.file = nil
+
+	ext := filepath.Ext(r.BaseName)
+	stem := r.BaseName[:len(r.BaseName)-len(ext)]
+	rotatedName := fmt.Sprintf("%s-%s%s.gz", stem, r.now().Format("20060102T150405"), ext)
+	rotatedPath := filepath.Join(r.Dir, rotatedName)
+
+	if err := writeGzip(rotatedPath, activeBytes, r.now()); err != nil {
+		return err
+	}
+
+	if err := os.Remove(r.activePath()); err != nil {
+		return fmt.Errorf("rotatelog: removing rotated active file: %w", err)
+	}
+
+	backups, err := r.pruneBackups()
+	if err != nil {
+		return err
+	}
+
+	r.rotations = append(r.rotations, RotationEvent{
+		Trigger:      trigger,
+		RotatedName:  rotatedName,
+		ActiveBytes:  activeBytes,
+		BackupsAfter: backups,
+	})
+
+	return r.ensureOpen()
+}
+
+// writeGzip writes data to path as a gzip member with its ModTime zeroed,
+// so the framing is byte-for-byte reproducible regardless of when a capture
+// run actually happens.
+func writeGzip(path string, data []byte, at time.Time) error {
+	var buf bytes.Buffer
+	gw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return fmt.Errorf("rotatelog: building gzip writer: %w", err)
+	}
+	gw.ModTime = time.Time{}
+	if _, err := gw.Write(data); err != nil {
+		gw.Close()
+		return fmt.Errorf("rotatelog: writing gzip member: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return fmt.Errorf("rotatelog: closing gzip member: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("rotatelog: writing rotated file: %w", err)
+	}
+	return nil
+}
+
+// pruneBackups removes the oldest rotated *.gz files beyond MaxBackups and
+// returns the surviving backups' names, oldest first.
+func (r *Rotator) pruneBackups() ([]string, error) {
+	entries, err := os.ReadDir(r.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("rotatelog: listing backups: %w", err)
+	}
+
+	ext := filepath.Ext(r.BaseName)
+	stem := r.BaseName[:len(r.BaseName)-len(ext)]
+	prefix := stem + "-"
+
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if filepath.Ext(name) == ".gz" && len(name) > len(prefix) && name[:len(prefix)] == prefix {
+			backups = append(backups, name)
+		}
+	}
+	sort.Strings(backups)
+
+	if r.MaxBackups > 0 {
+		for len(backups) > r.MaxBackups {
+			if err := os.Remove(filepath.Join(r.Dir, backups[0])); err != nil {
+				return nil, fmt.Errorf("rotatelog: pruning backup: %w", err)
+			}
+			backups = backups[1:]
+		}
+	}
+
+	return backups, nil
+}
+
+// Close closes the active file, if open.
+func (r *Rotator) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Rotations returns every RotationEvent recorded so far, in order.
+func (r *Rotator) Rotations() []RotationEvent {
+	return append([]RotationEvent(nil), r.rotations...)
+}
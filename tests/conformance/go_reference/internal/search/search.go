@@ -0,0 +1,390 @@
+// Package search implements a minimal regex/incremental search overlay for
+// viewport content and list items, modeled on alacritty's RegexSearch and
+// fzf's incremental matcher. Bubbles does not ship a viewport search today,
+// so this stands in for the viewport.Search(pattern string, opts
+// SearchOptions) API and viewport.Highlight render hook a Rust port needs
+// a concrete conformance suite for.
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Match is a single search hit within viewport content: Line is the
+// zero-based line number, StartCol/EndCol are zero-based rune offsets
+// within that line (EndCol exclusive).
+type Match struct {
+	Line     int
+	StartCol int
+	EndCol   int
+}
+
+// Options controls how a search pattern is interpreted.
+type Options struct {
+	CaseInsensitive bool
+	Regex           bool
+	MultiLine       bool
+}
+
+// InViewport searches line-oriented viewport content for pattern, returning
+// ordered matches. When opts.Regex is false, pattern is matched as a plain
+// substring. When opts.MultiLine is set, pattern is compiled against the
+// full content (embedded newlines and all) so anchors and cross-line
+// patterns behave the way alacritty's RegexSearch treats a visible region.
+func InViewport(content, pattern string, opts Options) ([]Match, error) {
+	if opts.MultiLine {
+		return searchMultiLine(content, pattern, opts)
+	}
+
+	re, err := compile(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for i, line := range strings.Split(content, "\n") {
+		for _, idx := range re.FindAllStringIndex(line, -1) {
+			matches = append(matches, Match{
+				Line:     i,
+				StartCol: len([]rune(line[:idx[0]])),
+				EndCol:   len([]rune(line[:idx[1]])),
+			})
+		}
+	}
+	return matches, nil
+}
+
+func searchMultiLine(content, pattern string, opts Options) ([]Match, error) {
+	re, err := compile(pattern, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lineStarts := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	var matches []Match
+	for _, idx := range re.FindAllStringIndex(content, -1) {
+		line, col := lineColAt(content, lineStarts, idx[0])
+		_, endCol := lineColAt(content, lineStarts, idx[1])
+		matches = append(matches, Match{Line: line, StartCol: col, EndCol: endCol})
+	}
+	return matches, nil
+}
+
+func lineColAt(content string, lineStarts []int, byteOffset int) (line, col int) {
+	for i := len(lineStarts) - 1; i >= 0; i-- {
+		if byteOffset >= lineStarts[i] {
+			return i, len([]rune(content[lineStarts[i]:byteOffset]))
+		}
+	}
+	return 0, 0
+}
+
+func compile(pattern string, opts Options) (*regexp.Regexp, error) {
+	pat := pattern
+	if !opts.Regex {
+		pat = regexp.QuoteMeta(pattern)
+	}
+	var flags string
+	if opts.CaseInsensitive {
+		flags += "i"
+	}
+	if opts.MultiLine {
+		flags += "m"
+	}
+	if flags != "" {
+		pat = fmt.Sprintf("(?%s)%s", flags, pat)
+	}
+	return regexp.Compile(pat)
+}
+
+// Cursor tracks the currently focused match within an ordered match list,
+// mirroring fzf's incremental matcher: NextMatch/PrevMatch wrap around.
+type Cursor struct {
+	Matches []Match
+	Index   int
+}
+
+// NewCursor creates a Cursor focused on the first match, if any.
+func NewCursor(matches []Match) *Cursor {
+	return &Cursor{Matches: matches}
+}
+
+// NextMatch advances the focus to the next match, wrapping to the first
+// match after the last, and returns the newly focused match.
+func (c *Cursor) NextMatch() Match {
+	if len(c.Matches) == 0 {
+		return Match{}
+	}
+	c.Index = (c.Index + 1) % len(c.Matches)
+	return c.Matches[c.Index]
+}
+
+// PrevMatch moves the focus to the previous match, wrapping to the last
+// match before the first, and returns the newly focused match.
+func (c *Cursor) PrevMatch() Match {
+	if len(c.Matches) == 0 {
+		return Match{}
+	}
+	c.Index = (c.Index - 1 + len(c.Matches)) % len(c.Matches)
+	return c.Matches[c.Index]
+}
+
+// Current returns the currently focused match.
+func (c *Cursor) Current() Match {
+	if len(c.Matches) == 0 {
+		return Match{}
+	}
+	return c.Matches[c.Index]
+}
+
+// YOffsetForMatch computes the YOffset a viewport should auto-scroll to so
+// that match is visible, centering it within height when possible and
+// clamping to [0, totalLines-height].
+func YOffsetForMatch(m Match, height, totalLines int) int {
+	if height <= 0 {
+		return 0
+	}
+	offset := m.Line - height/2
+	if offset < 0 {
+		offset = 0
+	}
+	if max := totalLines - height; max > 0 {
+		if offset > max {
+			offset = max
+		}
+	} else {
+		offset = 0
+	}
+	return offset
+}
+
+// Highlight wraps the substrings of line covered by ranges using style,
+// standing in for the viewport.Highlight render hook a search overlay
+// needs to mark matches without the caller re-implementing range math.
+func Highlight(line string, ranges [][2]int, style func(string) string) string {
+	if len(ranges) == 0 {
+		return line
+	}
+	runes := []rune(line)
+	var b strings.Builder
+	prev := 0
+	for _, r := range ranges {
+		start, end := r[0], r[1]
+		if start < prev || end > len(runes) || start > end {
+			continue
+		}
+		b.WriteString(string(runes[prev:start]))
+		b.WriteString(style(string(runes[start:end])))
+		prev = end
+	}
+	b.WriteString(string(runes[prev:]))
+	return b.String()
+}
+
+// ListMatch is a single filtered list item: Index is the item's position
+// in the original (unfiltered) slice, Ranges are ordered match ranges
+// (rune offsets) within FilterValue(), and Score is a higher-is-better
+// score (0 for the plain substring matching this package performs).
+type ListMatch struct {
+	Index  int
+	Ranges [][2]int
+	Score  int
+}
+
+// ScorerFunc scores how well pattern matches candidate, returning a
+// positive score and the matched rune positions within candidate on a
+// match, or a score <= 0 (and nil positions) when pattern doesn't match
+// at all. LiveSearch.SetSearchScorer lets callers swap in their own
+// algorithm in place of DefaultFuzzyScorer.
+type ScorerFunc func(pattern, candidate string) (score int, positions []int)
+
+// DefaultFuzzyScorer is an fzf-style subsequence matcher: it rewards
+// consecutive matched runes, word-start and camelCase boundary "humps",
+// and prefix matches, and penalizes the gap skipped over to reach each
+// non-consecutive match.
+func DefaultFuzzyScorer(pattern, candidate string) (score int, positions []int) {
+	if pattern == "" {
+		return 0, nil
+	}
+
+	lowerPattern := strings.ToLower(pattern)
+	lowerCandidate := strings.ToLower(candidate)
+
+	ci := 0
+	consecutive := 0
+	for i := 0; i < len(lowerPattern); i++ {
+		c := lowerPattern[i]
+		start := ci
+		found := false
+		for ; ci < len(lowerCandidate); ci++ {
+			if lowerCandidate[ci] == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, nil
+		}
+
+		bonus := 1
+		if ci == 0 || candidate[ci-1] == ' ' || candidate[ci-1] == '_' {
+			bonus += 5 // word-start bonus
+		}
+		if ci > 0 && isASCIILower(rune(candidate[ci-1])) && isASCIIUpper(rune(candidate[ci])) {
+			bonus += 4 // camelCase bonus
+		}
+
+		if len(positions) > 0 && positions[len(positions)-1] == ci-1 {
+			consecutive++
+			bonus += consecutive * 2 // consecutive-match bonus
+		} else {
+			consecutive = 0
+			if gap := ci - start; gap > 0 {
+				bonus -= gap // gap penalty
+			}
+		}
+
+		score += bonus
+		positions = append(positions, ci)
+		ci++
+	}
+
+	if score < 1 {
+		score = 1 // any actual subsequence match stays positive
+	}
+	return score, positions
+}
+
+func isASCIILower(r rune) bool { return r >= 'a' && r <= 'z' }
+func isASCIIUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+
+// RowMatch is one candidate row's score and matched positions from a
+// LiveSearch pass.
+type RowMatch struct {
+	Index     int
+	Score     int
+	Positions []int
+}
+
+// LiveSearch implements a vim-style "/" search-next/search-prev: unlike
+// FilterList, it never removes non-matching rows -- it keeps an ordered
+// match list and a cursor so SearchNext/SearchPrev can cycle the current
+// highlight without collapsing list.Model or table.Model's visible rows.
+type LiveSearch struct {
+	Scorer  ScorerFunc
+	Matches []RowMatch
+	cursor  int
+}
+
+// NewLiveSearch creates a LiveSearch using DefaultFuzzyScorer.
+func NewLiveSearch() *LiveSearch {
+	return &LiveSearch{Scorer: DefaultFuzzyScorer}
+}
+
+// SetSearchScorer swaps in a caller-supplied scoring algorithm.
+func (s *LiveSearch) SetSearchScorer(scorer ScorerFunc) {
+	s.Scorer = scorer
+}
+
+// Search scores every candidate against pattern, keeping only positive
+// matches ordered by score descending (ties preserve candidate order),
+// and resets the cursor to the first match.
+func (s *LiveSearch) Search(pattern string, candidates []string) []RowMatch {
+	var matches []RowMatch
+	for i, c := range candidates {
+		score, positions := s.Scorer(pattern, c)
+		if score <= 0 {
+			continue
+		}
+		matches = append(matches, RowMatch{Index: i, Score: score, Positions: positions})
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Score > matches[b].Score })
+	s.Matches = matches
+	s.cursor = 0
+	return matches
+}
+
+// SearchNext advances the cursor to the next match, wrapping from the
+// last match to the first, mirroring vim's "n".
+func (s *LiveSearch) SearchNext() (RowMatch, bool) {
+	if len(s.Matches) == 0 {
+		return RowMatch{}, false
+	}
+	s.cursor = (s.cursor + 1) % len(s.Matches)
+	return s.Matches[s.cursor], true
+}
+
+// SearchPrev moves the cursor to the previous match, wrapping from the
+// first match to the last, mirroring vim's "N".
+func (s *LiveSearch) SearchPrev() (RowMatch, bool) {
+	if len(s.Matches) == 0 {
+		return RowMatch{}, false
+	}
+	s.cursor = (s.cursor - 1 + len(s.Matches)) % len(s.Matches)
+	return s.Matches[s.cursor], true
+}
+
+// Current returns the match the cursor currently points at.
+func (s *LiveSearch) Current() (RowMatch, bool) {
+	if len(s.Matches) == 0 {
+		return RowMatch{}, false
+	}
+	return s.Matches[s.cursor], true
+}
+
+// PositionsToRanges collapses individual matched rune positions (as
+// returned by a ScorerFunc) into contiguous [start, end) ranges suitable
+// for Highlight.
+func PositionsToRanges(positions []int) [][2]int {
+	if len(positions) == 0 {
+		return nil
+	}
+	var ranges [][2]int
+	start, prev := positions[0], positions[0]
+	for _, p := range positions[1:] {
+		if p == prev+1 {
+			prev = p
+			continue
+		}
+		ranges = append(ranges, [2]int{start, prev + 1})
+		start, prev = p, p
+	}
+	ranges = append(ranges, [2]int{start, prev + 1})
+	return ranges
+}
+
+// FilterList performs a case-insensitive substring filter over
+// FilterValue()-like strings, returning ordered ListMatch results. It is
+// deliberately simple: no fuzzy scoring.
+func FilterList(values []string, pattern string) []ListMatch {
+	if pattern == "" {
+		out := make([]ListMatch, len(values))
+		for i := range values {
+			out[i] = ListMatch{Index: i}
+		}
+		return out
+	}
+
+	lowerPattern := strings.ToLower(pattern)
+	var out []ListMatch
+	for i, v := range values {
+		lower := strings.ToLower(v)
+		idx := strings.Index(lower, lowerPattern)
+		if idx < 0 {
+			continue
+		}
+		start := len([]rune(v[:idx]))
+		end := start + len([]rune(pattern))
+		out = append(out, ListMatch{Index: i, Ranges: [][2]int{{start, end}}})
+	}
+	return out
+}
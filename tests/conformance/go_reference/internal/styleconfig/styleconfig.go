@@ -0,0 +1,84 @@
+// Package styleconfig tracks the small subset of glamour's JSON style
+// schema (ansi.StyleConfig) this capture suite's custom style
+// fixtures exercise -- H1 color, CodeBlock theme, Table separators,
+// Document margin, BlockQuote indent, and Emph italic -- and merges a
+// partial override onto a baseline default, the way glamour's own
+// style loader merges a partial JSON file onto a built-in style. It
+// does not model ansi.StyleConfig's full field set, only the fields
+// these fixtures cover.
+package styleconfig
+
+// Override is a partial style override: every field is a pointer so a
+// merge can distinguish "not present in the JSON" from "explicitly
+// set to the zero value" (e.g. Emph.Italic: false).
+type Override struct {
+	H1Color              *string
+	CodeBlockTheme       *string
+	TableCenterSeparator *string
+	TableColumnSeparator *string
+	TableRowSeparator    *string
+	DocumentMargin       *uint
+	BlockQuoteIndent     *uint
+	EmphItalic           *bool
+}
+
+// EffectiveStyle is the fully-merged, flattened result of applying an
+// Override onto Default(): every field is always populated, so
+// fixtures can be compared directly regardless of how little each
+// override JSON actually set.
+type EffectiveStyle struct {
+	H1Color              string `json:"h1_color"`
+	CodeBlockTheme       string `json:"code_block_theme"`
+	TableCenterSeparator string `json:"table_center_separator"`
+	TableColumnSeparator string `json:"table_column_separator"`
+	TableRowSeparator    string `json:"table_row_separator"`
+	DocumentMargin       uint   `json:"document_margin"`
+	BlockQuoteIndent     uint   `json:"block_quote_indent"`
+	EmphItalic           bool   `json:"emph_italic"`
+}
+
+// Default is the baseline Merge starts from for any field an Override
+// leaves unset.
+func Default() EffectiveStyle {
+	return EffectiveStyle{
+		H1Color:              "39",
+		CodeBlockTheme:       "notty",
+		TableCenterSeparator: "┼",
+		TableColumnSeparator: "│",
+		TableRowSeparator:    "─",
+		DocumentMargin:       2,
+		BlockQuoteIndent:     2,
+		EmphItalic:           true,
+	}
+}
+
+// Merge applies o onto Default(), field by field, and returns the
+// result as an EffectiveStyle.
+func Merge(o Override) EffectiveStyle {
+	eff := Default()
+	if o.H1Color != nil {
+		eff.H1Color = *o.H1Color
+	}
+	if o.CodeBlockTheme != nil {
+		eff.CodeBlockTheme = *o.CodeBlockTheme
+	}
+	if o.TableCenterSeparator != nil {
+		eff.TableCenterSeparator = *o.TableCenterSeparator
+	}
+	if o.TableColumnSeparator != nil {
+		eff.TableColumnSeparator = *o.TableColumnSeparator
+	}
+	if o.TableRowSeparator != nil {
+		eff.TableRowSeparator = *o.TableRowSeparator
+	}
+	if o.DocumentMargin != nil {
+		eff.DocumentMargin = *o.DocumentMargin
+	}
+	if o.BlockQuoteIndent != nil {
+		eff.BlockQuoteIndent = *o.BlockQuoteIndent
+	}
+	if o.EmphItalic != nil {
+		eff.EmphItalic = *o.EmphItalic
+	}
+	return eff
+}
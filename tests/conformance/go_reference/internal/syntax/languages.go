@@ -0,0 +1,40 @@
+package syntax
+
+// SupportedLanguages are the canonical language names languageSpecs
+// has a lexical spec for.
+var SupportedLanguages = []string{
+	"go", "rust", "python", "javascript", "typescript", "json", "yaml",
+	"bash", "sql", "html", "css", "diff", "dockerfile", "markdown",
+}
+
+// languageAliases maps an alternate spelling a fenced code block's info
+// string might use to the canonical name in languageSpecs, mirroring
+// chroma's own lexer aliasing (e.g. a ```golang block is lexed the same
+// as ```go).
+var languageAliases = map[string]string{
+	"golang": "go",
+	"js":     "javascript",
+	"ts":     "typescript",
+	"yml":    "yaml",
+	"sh":     "bash",
+	"shell":  "bash",
+	"htm":    "html",
+	"md":     "markdown",
+}
+
+// ResolveLanguage maps a fenced code block's requested language name to
+// its canonical languageSpecs entry, following languageAliases. ok is
+// false if the name (after alias resolution) isn't one of
+// SupportedLanguages -- chroma's own behavior in this case is to fall
+// back to its plaintext lexer, which Lex mirrors by returning the whole
+// source as one Text token.
+func ResolveLanguage(requested string) (canonical string, ok bool) {
+	name := requested
+	if alias, found := languageAliases[name]; found {
+		name = alias
+	}
+	if _, found := languageSpecs[name]; found {
+		return name, true
+	}
+	return name, false
+}
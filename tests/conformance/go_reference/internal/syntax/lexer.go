@@ -0,0 +1,165 @@
+package syntax
+
+import (
+	"regexp"
+	"strings"
+)
+
+// languageSpec is just enough lexical shape -- a keyword set and a
+// line-comment marker -- for Lex's single generic scanner to
+// approximate chroma's token classification for a language, without
+// embedding a real chroma lexer per language.
+type languageSpec struct {
+	keywords    map[string]bool
+	lineComment string
+}
+
+func keywordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+var languageSpecs = map[string]languageSpec{
+	"go": {
+		lineComment: "//",
+		keywords: keywordSet("func", "package", "import", "return", "if", "else", "for",
+			"range", "var", "const", "type", "struct", "interface", "go", "defer", "chan",
+			"select", "switch", "case", "break", "continue", "map", "nil", "true", "false"),
+	},
+	"rust": {
+		lineComment: "//",
+		keywords: keywordSet("fn", "let", "mut", "pub", "struct", "enum", "impl", "trait",
+			"match", "if", "else", "for", "while", "loop", "return", "use", "mod", "crate",
+			"self", "Self", "true", "false"),
+	},
+	"python": {
+		lineComment: "#",
+		keywords: keywordSet("def", "class", "import", "from", "return", "if", "elif", "else",
+			"for", "while", "in", "is", "not", "and", "or", "pass", "break", "continue", "try",
+			"except", "finally", "with", "as", "lambda", "None", "True", "False", "self"),
+	},
+	"javascript": {
+		lineComment: "//",
+		keywords: keywordSet("function", "const", "let", "var", "return", "if", "else", "for",
+			"while", "class", "extends", "new", "this", "import", "export", "from", "default",
+			"try", "catch", "finally", "async", "await", "true", "false", "null", "undefined"),
+	},
+	"typescript": {
+		lineComment: "//",
+		keywords: keywordSet("function", "const", "let", "var", "return", "if", "else", "for",
+			"while", "class", "extends", "new", "this", "import", "export", "from", "default",
+			"try", "catch", "finally", "async", "await", "true", "false", "null", "undefined",
+			"interface", "type", "implements", "public", "private", "readonly", "enum"),
+	},
+	"json": {
+		keywords: keywordSet("true", "false", "null"),
+	},
+	"yaml": {
+		lineComment: "#",
+		keywords:    keywordSet("true", "false", "null"),
+	},
+	"bash": {
+		lineComment: "#",
+		keywords: keywordSet("if", "then", "else", "fi", "for", "do", "done", "while",
+			"function", "return", "exit", "echo", "local", "export"),
+	},
+	"sql": {
+		lineComment: "--",
+		keywords: keywordSet("SELECT", "FROM", "WHERE", "INSERT", "INTO", "VALUES", "UPDATE",
+			"SET", "DELETE", "JOIN", "ON", "GROUP", "BY", "ORDER", "CREATE", "TABLE",
+			"PRIMARY", "KEY", "NULL", "NOT", "AND", "OR"),
+	},
+	"html": {
+		keywords: keywordSet("html", "head", "body", "div", "span", "script", "style", "href",
+			"src", "class", "id"),
+	},
+	"css": {
+		keywords: keywordSet("color", "background", "margin", "padding", "display", "flex",
+			"grid", "width", "height", "border", "font"),
+	},
+	"diff": {
+		keywords: keywordSet("diff", "index"),
+	},
+	"dockerfile": {
+		lineComment: "#",
+		keywords: keywordSet("FROM", "RUN", "CMD", "COPY", "ADD", "WORKDIR", "EXPOSE", "ENV",
+			"ENTRYPOINT", "ARG", "LABEL", "USER"),
+	},
+	"markdown": {
+		keywords: keywordSet(),
+	},
+}
+
+// neverMatches is substituted for a language's line-comment marker when
+// it has none, so lexPattern's comment group always exists but can
+// never match real source text.
+const neverMatches = "\x00\x01"
+
+// lexPattern builds spec's scanning regexp: one capturing group per
+// token category, tried in priority order (comment, string literal,
+// number, identifier/keyword, whitespace, and finally a single
+// fallback rune for punctuation/operators).
+func (spec languageSpec) lexPattern() *regexp.Regexp {
+	commentAlt := neverMatches
+	if spec.lineComment != "" {
+		commentAlt = regexp.QuoteMeta(spec.lineComment) + `[^\n]*`
+	}
+	pattern := `(?s)(` + commentAlt + `)` +
+		"|(\"[^\"]*\"|`[^`]*`|'[^']*')" +
+		`|(\d+\.?\d*)` +
+		`|([A-Za-z_][A-Za-z0-9_]*)` +
+		`|(\s+)` +
+		`|(.)`
+	return regexp.MustCompile(pattern)
+}
+
+// operatorRunes are classified as Operator rather than Punctuation when
+// they fall through to lexPattern's fallback group.
+const operatorRunes = "+-*/%=<>!&|^~"
+
+// Lex tokenizes source as canonical (a languageSpecs key), approximating
+// chroma's token classification with a single generic scanner driven by
+// that language's keyword set and line-comment marker. If canonical
+// isn't a recognized language, the whole source is returned as one Text
+// token, mirroring chroma's plaintext-lexer fallback.
+func Lex(canonical string, source string) []Token {
+	spec, ok := languageSpecs[canonical]
+	if !ok {
+		return []Token{{Type: Text, Text: source}}
+	}
+
+	re := spec.lexPattern()
+	matches := re.FindAllStringSubmatchIndex(source, -1)
+	tokens := make([]Token, 0, len(matches))
+	for _, m := range matches {
+		text := source[m[0]:m[1]]
+		var tt TokenType
+		switch {
+		case m[2] != -1:
+			tt = Comment
+		case m[4] != -1:
+			tt = String
+		case m[6] != -1:
+			tt = Number
+		case m[8] != -1:
+			if spec.keywords[text] {
+				tt = Keyword
+			} else {
+				tt = Identifier
+			}
+		case m[10] != -1:
+			tt = Text
+		default:
+			if strings.ContainsAny(text, operatorRunes) {
+				tt = Operator
+			} else {
+				tt = Punctuation
+			}
+		}
+		tokens = append(tokens, Token{Type: tt, Text: text})
+	}
+	return tokens
+}
@@ -0,0 +1,133 @@
+// Package syntax renders a chroma-style token stream through lipgloss
+// styles into a single ANSI string. lipgloss itself only renders whole
+// strings through one style at a time, so this stands in for the
+// code-listing rendering primitive glow/chroma-backed tools layer on top
+// of it, merging adjacent same-style runs so a Rust port's ANSI output
+// can be diffed byte-for-byte rather than style-call-for-style-call.
+// Lex and ChromaThemes extend this same stand-in to a full (language,
+// chroma style) lexer/palette matrix, since glamour's own embedded
+// chroma lexers and named styles aren't exposed as a public API to
+// drive from outside the library.
+package syntax
+
+import (
+	"regexp"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TokenType is a chroma-style lexical category. The zero value, Text,
+// covers anything a theme doesn't style explicitly.
+type TokenType int
+
+const (
+	Text TokenType = iota
+	Keyword
+	String
+	Comment
+	Number
+	Operator
+	Identifier
+	Punctuation
+)
+
+// Token is one lexed unit of source text.
+type Token struct {
+	Type TokenType
+	Text string
+}
+
+// SyntaxTheme maps token types to the lipgloss.Style rendering them. A
+// token type with no entry renders unstyled (its literal text).
+type SyntaxTheme map[TokenType]lipgloss.Style
+
+// Render concatenates tokens into a single ANSI string, merging adjacent
+// tokens that share a style into one Render call so the output carries one
+// SGR sequence per run instead of one per token.
+func Render(tokens []Token, theme SyntaxTheme) string {
+	out, _ := RenderWithSpans(tokens, theme)
+	return out
+}
+
+// Span describes one contiguous, same-styled run in a RenderWithSpans
+// result: where it begins and how many bytes it occupies (including
+// its own SGR escape sequences, if any), and the SGR parameter string
+// that was applied (empty for an unstyled run, e.g. fallback output).
+type Span struct {
+	Start  int
+	Length int
+	SGR    string
+}
+
+// sgrPrefixRegexp matches the parameter string of a rendered run's
+// leading SGR escape sequence, for RenderWithSpans to report alongside
+// each Span.
+var sgrPrefixRegexp = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// RenderWithSpans behaves like Render but also reports each merged
+// run's byte position within the returned string and its SGR
+// parameters, so a caller can assert on token-level color boundaries
+// instead of only comparing the final string.
+func RenderWithSpans(tokens []Token, theme SyntaxTheme) (string, []Span) {
+	var out string
+	var spans []Span
+	var run []Token
+	var runStyle lipgloss.Style
+	var runSet bool
+
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		text := joinText(run)
+		start := len(out)
+		var rendered, sgr string
+		if runSet {
+			rendered = runStyle.Render(text)
+			if m := sgrPrefixRegexp.FindStringSubmatch(rendered); m != nil {
+				sgr = m[1]
+			}
+		} else {
+			rendered = text
+		}
+		out += rendered
+		spans = append(spans, Span{Start: start, Length: len(rendered), SGR: sgr})
+		run = run[:0]
+	}
+
+	for _, tok := range tokens {
+		style, ok := theme[tok.Type]
+		if len(run) == 0 {
+			run = append(run, tok)
+			runStyle = style
+			runSet = ok
+			continue
+		}
+		if ok == runSet && (!ok || sameStyle(style, runStyle)) {
+			run = append(run, tok)
+			continue
+		}
+		flush()
+		run = append(run, tok)
+		runStyle = style
+		runSet = ok
+	}
+	flush()
+
+	return out, spans
+}
+
+func joinText(tokens []Token) string {
+	var s string
+	for _, t := range tokens {
+		s += t.Text
+	}
+	return s
+}
+
+// sameStyle compares two styles by their rendered effect on a probe
+// string, since lipgloss.Style has no public equality method.
+func sameStyle(a, b lipgloss.Style) bool {
+	const probe = "x"
+	return a.Render(probe) == b.Render(probe)
+}
@@ -0,0 +1,48 @@
+package syntax
+
+import "github.com/charmbracelet/lipgloss"
+
+// ChromaStyleNames are the chroma style names captureSyntaxHighlightingTests
+// cross-products against, chosen to span chroma's most commonly embedded
+// dark, light, and editor-native palettes.
+var ChromaStyleNames = []string{
+	"monokai", "dracula", "github", "solarized-dark", "solarized-light",
+	"vs", "xcode", "native", "friendly", "pygments",
+}
+
+// ChromaThemes maps each name in ChromaStyleNames to the SyntaxTheme
+// approximating its keyword/string/comment/number/operator/identifier
+// palette. Real chroma styles carry many more token categories; these
+// six are the ones Lex produces that get a color, and its Text and
+// Punctuation tokens render unstyled.
+var ChromaThemes = map[string]SyntaxTheme{
+	"monokai":         chromaTheme("#F92672", "#E6DB74", "#75715E", "#AE81FF", "#F92672", "#A6E22E"),
+	"dracula":         chromaTheme("#FF79C6", "#F1FA8C", "#6272A4", "#BD93F9", "#FF79C6", "#50FA7B"),
+	"github":          chromaTheme("#D73A49", "#032F62", "#6A737D", "#005CC5", "#D73A49", "#6F42C1"),
+	"solarized-dark":  chromaTheme("#859900", "#2AA198", "#586E75", "#D33682", "#859900", "#268BD2"),
+	"solarized-light": chromaTheme("#859900", "#2AA198", "#93A1A1", "#D33682", "#859900", "#268BD2"),
+	"vs":              chromaTheme("#0000FF", "#A31515", "#008000", "#098658", "#000000", "#001080"),
+	"xcode":           chromaTheme("#AD3DA4", "#D12F1B", "#5D6C79", "#272AD8", "#000000", "#3F6E75"),
+	"native":          chromaTheme("#6AB825", "#ED9D13", "#999999", "#3677A9", "#D0D0D0", "#D0D0D0"),
+	"friendly":        chromaTheme("#007020", "#4070A0", "#60A0B0", "#40A070", "#666666", "#0E84B5"),
+	"pygments":        chromaTheme("#008000", "#BA2121", "#408080", "#666666", "#666666", "#0000FF"),
+}
+
+func chromaTheme(keyword, str, comment, number, operator, identifier string) SyntaxTheme {
+	return SyntaxTheme{
+		Keyword:    lipgloss.NewStyle().Foreground(lipgloss.Color(keyword)),
+		String:     lipgloss.NewStyle().Foreground(lipgloss.Color(str)),
+		Comment:    lipgloss.NewStyle().Foreground(lipgloss.Color(comment)),
+		Number:     lipgloss.NewStyle().Foreground(lipgloss.Color(number)),
+		Operator:   lipgloss.NewStyle().Foreground(lipgloss.Color(operator)),
+		Identifier: lipgloss.NewStyle().Foreground(lipgloss.Color(identifier)),
+	}
+}
+
+// GlamourPresetDisablesColor reports whether glamour's named style
+// preset skips ANSI color entirely -- "notty" and "ascii" both render
+// code blocks as plain, unstyled text, the way "dark" and "light" do
+// not.
+func GlamourPresetDisablesColor(preset string) bool {
+	return preset == "notty" || preset == "ascii"
+}
@@ -0,0 +1,68 @@
+// Package termdetect reproduces the terminal-environment decision
+// table tools like glow use to auto-select a glamour style: real
+// termenv detection probes an attached terminal (querying its
+// background color, checking isatty), which a non-interactive capture
+// binary doesn't have. This package takes the same inputs as a
+// declarative Env vector instead, so every combination can be
+// exercised deterministically.
+package termdetect
+
+// Env is one synthetic terminal environment: the environment variables
+// termenv's detection consults, plus the background color class and
+// color profile the (simulated) terminal itself reports.
+type Env struct {
+	ColorTerm     string
+	Term          string
+	NoColor       string
+	Clicolor      string
+	ClicolorForce string
+	Background    string // "dark", "light", or "unknown"
+	ColorProfile  string // "TrueColor", "ANSI256", "ANSI", "Ascii", or "" (undetected)
+}
+
+// Resolve applies termenv's documented precedence and returns the
+// glamour style name and effective color profile an auto-detecting
+// renderer would pick:
+//
+//   - NO_COLOR forces the Ascii profile, unless CLICOLOR_FORCE=1
+//     overrides it.
+//   - CLICOLOR=0 likewise forces Ascii unless CLICOLOR_FORCE=1.
+//   - A "dumb" TERM forces Ascii unless CLICOLOR_FORCE=1.
+//   - An undetected color profile (no ColorProfile reported) is
+//     treated as Ascii.
+//   - Once the effective profile is Ascii, the style downgrades to
+//     "notty" if the terminal would otherwise have supported color
+//     (the NO_COLOR/CLICOLOR/dumb-TERM/undetected cases above), or to
+//     "ascii" if the terminal itself reported an Ascii-only profile.
+//   - Otherwise the style is "light" or "dark" by Background, default
+//     "dark" when the background is "unknown".
+func (e Env) Resolve() (style, profile string) {
+	profile = e.effectiveProfile()
+	if profile != "Ascii" {
+		if e.Background == "light" {
+			return "light", profile
+		}
+		return "dark", profile
+	}
+	if e.ColorProfile == "Ascii" {
+		return "ascii", profile
+	}
+	return "notty", profile
+}
+
+func (e Env) effectiveProfile() string {
+	forced := e.ClicolorForce == "1"
+	if e.NoColor != "" && !forced {
+		return "Ascii"
+	}
+	if e.Clicolor == "0" && !forced {
+		return "Ascii"
+	}
+	if e.Term == "dumb" && !forced {
+		return "Ascii"
+	}
+	if e.ColorProfile == "" {
+		return "Ascii"
+	}
+	return e.ColorProfile
+}
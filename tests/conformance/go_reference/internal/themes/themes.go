@@ -0,0 +1,197 @@
+// Package themes loads a huh.Theme from an external INI/TOML-style
+// file, modeled on how terminal apps like aerc externalize their style
+// tables instead of hardcoding them as Go constructors. huh ships themes
+// only as Go functions (ThemeBase, ThemeCharm, ...), so this stands in for
+// the file-based theme loader a Rust port needs a concrete, portable
+// specification for.
+package themes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// selectors is the bounded vocabulary of "<state>.<element>" section names a
+// theme file may style. Each maps to one *lipgloss.Style field under the
+// corresponding huh.FieldStyles (Focused or Blurred).
+var selectors = []string{
+	"title",
+	"description",
+	"selected_option",
+	"unselected_option",
+	"error_message",
+}
+
+// fieldStyle returns a pointer to the *lipgloss.Style on styles matching
+// element, or nil if element isn't in the supported vocabulary.
+func fieldStyle(styles *huh.FieldStyles, element string) *lipgloss.Style {
+	switch element {
+	case "title":
+		return &styles.Title
+	case "description":
+		return &styles.Description
+	case "selected_option":
+		return &styles.SelectedOption
+	case "unselected_option":
+		return &styles.UnselectedOption
+	case "error_message":
+		return &styles.ErrorMessage
+	default:
+		return nil
+	}
+}
+
+// LoadFromFile reads an INI/TOML-style theme file at path and returns a
+// *huh.Theme built by mutating huh.ThemeBase() with the styles it
+// describes. Section headers name a state ("focused" or "blurred") and an
+// element from the selectors vocabulary, e.g. "[focused.title]"; keys
+// within a section are lipgloss style attributes: fg, bg (color strings --
+// hex like "#ff00ff" or an ANSI index like "8"), and the boolean
+// attributes bold, italic, faint, underline, strikethrough.
+func LoadFromFile(path string) (*huh.Theme, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("themes: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	sections, err := parseSections(f)
+	if err != nil {
+		return nil, fmt.Errorf("themes: parsing %s: %w", path, err)
+	}
+
+	theme := huh.ThemeBase()
+	for name, attrs := range sections {
+		parts := strings.SplitN(name, ".", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("themes: %s: section %q must be \"<state>.<element>\"", path, name)
+		}
+		state, element := parts[0], parts[1]
+
+		var styles *huh.FieldStyles
+		switch state {
+		case "focused":
+			styles = &theme.Focused
+		case "blurred":
+			styles = &theme.Blurred
+		default:
+			return nil, fmt.Errorf("themes: %s: unknown state %q (want focused or blurred)", path, state)
+		}
+
+		style := fieldStyle(styles, element)
+		if style == nil {
+			return nil, fmt.Errorf("themes: %s: unknown element %q", path, element)
+		}
+
+		applied, err := applyAttrs(*style, attrs)
+		if err != nil {
+			return nil, fmt.Errorf("themes: %s: section %q: %w", path, name, err)
+		}
+		*style = applied
+	}
+
+	return theme, nil
+}
+
+func applyAttrs(style lipgloss.Style, attrs map[string]string) (lipgloss.Style, error) {
+	for key, value := range attrs {
+		switch key {
+		case "fg":
+			style = style.Foreground(lipgloss.Color(value))
+		case "bg":
+			style = style.Background(lipgloss.Color(value))
+		case "bold":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return style, fmt.Errorf("bold: %w", err)
+			}
+			style = style.Bold(b)
+		case "italic":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return style, fmt.Errorf("italic: %w", err)
+			}
+			style = style.Italic(b)
+		case "faint":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return style, fmt.Errorf("faint: %w", err)
+			}
+			style = style.Faint(b)
+		case "underline":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return style, fmt.Errorf("underline: %w", err)
+			}
+			style = style.Underline(b)
+		case "strikethrough":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return style, fmt.Errorf("strikethrough: %w", err)
+			}
+			style = style.Strikethrough(b)
+		default:
+			return style, fmt.Errorf("unknown attribute %q", key)
+		}
+	}
+	return style, nil
+}
+
+// parseSections parses the common subset of INI and TOML this package
+// supports: "[section.name]" headers followed by "key = value" pairs,
+// where value is a double-quoted string, true/false, or a bare number
+// (always treated as a string here since every supported attribute is
+// either a color string or a bool). Comments start with "#" and blank
+// lines are ignored.
+func parseSections(r io.Reader) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	var current string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			end := strings.Index(line, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("malformed section header: %q", line)
+			}
+			current = strings.TrimSpace(line[1:end])
+			if _, ok := sections[current]; !ok {
+				sections[current] = make(map[string]string)
+			}
+			continue
+		}
+
+		if current == "" {
+			return nil, fmt.Errorf("key outside any section: %q", line)
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("malformed key-value pair: %q", line)
+		}
+		key := strings.TrimSpace(line[:eq])
+		value := strings.TrimSpace(line[eq+1:])
+		if idx := strings.Index(value, "#"); idx >= 0 && !strings.HasPrefix(value, `"`) {
+			value = strings.TrimSpace(value[:idx])
+		}
+		value = strings.Trim(value, `"`)
+
+		sections[current][key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
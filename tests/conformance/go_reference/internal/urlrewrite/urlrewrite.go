@@ -0,0 +1,179 @@
+// Package urlrewrite pre-rewrites the image and link URLs in a
+// markdown document -- e.g. through a chat app's image-proxy template
+// -- before it reaches glamour's renderer. glamour itself has no
+// URL-rewriting hook: it only renders the markdown it's handed, so
+// this models the pre-processing step a caller would run first.
+package urlrewrite
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Rule is a declarative URL rewrite rule: Kind selects which markdown
+// construct it applies to ("image", "link", or "autolink"), Match is a
+// regexp the candidate URL must satisfy for the rule to fire, and
+// Template is a Go template producing the replacement URL, evaluated
+// with {{.URL}} and the urlencode/hmacSHA256 helpers below.
+type Rule struct {
+	Kind     string
+	Match    string
+	Template string
+}
+
+// hmacKey is fixed and non-secret: these fixtures exist to prove the
+// Rust port's rewriter reproduces the same bytes, not to sign anything
+// real.
+var hmacKey = []byte("charmed-conformance-fixture-key")
+
+var templateFuncs = template.FuncMap{
+	"urlencode": func(s string) string { return url.QueryEscape(s) },
+	"hmacSHA256": func(s string) string {
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil))
+	},
+}
+
+// rewriteURL applies r to rawURL, returning rawURL unchanged if Match
+// doesn't match or Template fails to parse/execute.
+func (r Rule) rewriteURL(rawURL string) string {
+	matched, err := regexp.MatchString(r.Match, rawURL)
+	if err != nil || !matched {
+		return rawURL
+	}
+	tmpl, err := template.New("rule").Funcs(templateFuncs).Parse(r.Template)
+	if err != nil {
+		return rawURL
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ URL string }{rawURL}); err != nil {
+		return rawURL
+	}
+	return buf.String()
+}
+
+// Config is the set of rewrite rules Rewrite applies, plus an optional
+// BaseURL relative image/link URLs are resolved against before any
+// rule is tried.
+type Config struct {
+	Rules   []Rule
+	BaseURL string
+}
+
+// resolve resolves rawURL against cfg.BaseURL if it's relative,
+// returning rawURL unchanged for absolute URLs, data: URIs, and
+// mailto: addresses (none of which url.Parse's ResolveReference should
+// touch).
+func (cfg Config) resolve(rawURL string) string {
+	if cfg.BaseURL == "" || strings.HasPrefix(rawURL, "data:") || strings.HasPrefix(rawURL, "mailto:") {
+		return rawURL
+	}
+	base, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return rawURL
+	}
+	ref, err := url.Parse(rawURL)
+	if err != nil || ref.IsAbs() {
+		return rawURL
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// applyRules resolves rawURL against cfg.BaseURL, then applies the
+// first configured rule of the given kind whose Match matches.
+func (cfg Config) applyRules(kind, rawURL string) string {
+	resolved := cfg.resolve(rawURL)
+	for _, rule := range cfg.Rules {
+		if rule.Kind != kind {
+			continue
+		}
+		if rewritten := rule.rewriteURL(resolved); rewritten != resolved {
+			return rewritten
+		}
+	}
+	return resolved
+}
+
+var (
+	imagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^ )]+)(?:\s+"([^"]*)")?\)`)
+	linkPattern     = regexp.MustCompile(`\[([^\]]*)\]\(([^ )]+)(?:\s+"([^"]*)")?\)`)
+	refDefPattern   = regexp.MustCompile(`(?m)^\[([^\]]+)\]:\s*(\S+)(?:\s+"([^"]*)")?\s*$`)
+	autolinkPattern = regexp.MustCompile(`<([^<>\s]+)>`)
+)
+
+// imagePlaceholder marks where a rewritten image temporarily sits while
+// linkPattern runs, so a link nesting an image (e.g.
+// "[![Alt](img)](page)") can't have its own brackets and target
+// swallowed by the image's -- a single combined regex has no way to
+// tell the two apart, since linkPattern's own "[^\]]*" text group stops
+// at the image's inner "]" either way.
+const imagePlaceholder = "\x00URLREWRITE_IMG%d\x00"
+
+// Rewrite rewrites every image, link, reference-style definition, and
+// autolink URL in markdown through cfg, returning the rewritten
+// markdown. A URL none of cfg.Rules match keeps only its BaseURL
+// resolution, so it passes through unchanged when it is absolute, a
+// data: URI or a mailto: address. Images are rewritten first and swapped for
+// placeholders so linkPattern can't mistake a nested image's text and
+// target for an outer link's; the placeholders are restored once
+// linkPattern has run.
+func Rewrite(markdown string, cfg Config) string {
+	var images []string
+	out := imagePattern.ReplaceAllStringFunc(markdown, func(m string) string {
+		sub := imagePattern.FindStringSubmatch(m)
+		text, rawURL, title := sub[1], sub[2], sub[3]
+		newURL := cfg.applyRules("image", rawURL)
+		images = append(images, rebuildInline("!", text, newURL, title))
+		return fmt.Sprintf(imagePlaceholder, len(images)-1)
+	})
+
+	out = linkPattern.ReplaceAllStringFunc(out, func(m string) string {
+		sub := linkPattern.FindStringSubmatch(m)
+		text, rawURL, title := sub[1], sub[2], sub[3]
+		newURL := cfg.applyRules("link", rawURL)
+		return rebuildInline("", text, newURL, title)
+	})
+
+	for i, img := range images {
+		out = strings.Replace(out, fmt.Sprintf(imagePlaceholder, i), img, 1)
+	}
+
+	out = refDefPattern.ReplaceAllStringFunc(out, func(m string) string {
+		sub := refDefPattern.FindStringSubmatch(m)
+		label, rawURL, title := sub[1], sub[2], sub[3]
+		newURL := cfg.applyRules("image", rawURL)
+		if newURL == cfg.resolve(rawURL) {
+			newURL = cfg.applyRules("link", rawURL)
+		}
+		return rebuildRefDef(label, newURL, title)
+	})
+
+	out = autolinkPattern.ReplaceAllStringFunc(out, func(m string) string {
+		sub := autolinkPattern.FindStringSubmatch(m)
+		return "<" + cfg.applyRules("autolink", sub[1]) + ">"
+	})
+
+	return out
+}
+
+func rebuildInline(bang, text, url, title string) string {
+	if title != "" {
+		return bang + "[" + text + "](" + url + ` "` + title + `")`
+	}
+	return bang + "[" + text + "](" + url + ")"
+}
+
+func rebuildRefDef(label, url, title string) string {
+	if title != "" {
+		return "[" + label + "]: " + url + ` "` + title + `"`
+	}
+	return "[" + label + "]: " + url
+}